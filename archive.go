@@ -0,0 +1,320 @@
+package qrc
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArchiveFormat is a type of archive which can be read by Archive.
+type ArchiveFormat int
+
+const (
+	ArchiveZip     ArchiveFormat = iota + 1 // also APK and JAR
+	ArchiveTar                              // POSIX, GNU, or PAX
+	ArchiveTarGzip                          // tar.gz or tgz
+)
+
+func (f ArchiveFormat) String() string {
+	switch f {
+	case ArchiveZip:
+		return "zip"
+	case ArchiveTar:
+		return "tar"
+	case ArchiveTarGzip:
+		return "tar.gz"
+	}
+	return fmt.Sprintf("ArchiveFormat(%d)", int(f))
+}
+
+// Archive reads RCC files (or files containing them, like Qt libraries) from a
+// zip (including APKs), tar, or gzipped tar archive without unpacking it.
+// Stored zip members and non-sparse tar members are read directly from the
+// archive. Other members are decompressed into memory, or into a temporary
+// file if they are larger than ReaderOptions.SpillSize. Since gzipped tar
+// archives can't be read randomly, each Open, Members, or Scan reads the
+// archive from the beginning.
+type Archive struct {
+	r      io.ReaderAt
+	size   int64
+	format ArchiveFormat
+	opt    ReaderOptions
+	zip    *zip.Reader // if ArchiveZip
+}
+
+// ArchiveMember is the contents of a regular file opened from an Archive. It
+// must be closed to remove the temporary file, if any.
+type ArchiveMember struct {
+	name    string
+	r       io.ReaderAt
+	size    int64
+	release func() error // or nil
+}
+
+var _ io.ReaderAt = (*ArchiveMember)(nil)
+
+// ArchiveRCC is a RCC file found by Archive.Scan.
+type ArchiveRCC struct {
+	Member      *ArchiveMember // shared by all RCC files found in it
+	EmbeddedRCC                // Offset is relative to the member
+}
+
+// archiveFile is a regular file in an archive. It is only valid until the next
+// one is read.
+type archiveFile struct {
+	name string
+	size int64
+	sr   *io.SectionReader             // if the data is stored contiguously in the archive
+	open func() (io.ReadCloser, error) // otherwise
+}
+
+// OpenArchive detects the format of the archive in r, which has the provided
+// size. If it isn't a supported archive (including if it is a RCC file), an
+// error wrapping ErrUnknownArchive is returned. If opt is nil, the default limits are used. The options are also
+// used for the readers returned by Scan.
+func OpenArchive(r io.ReaderAt, size int64, opt *ReaderOptions) (*Archive, error) {
+	a := &Archive{
+		r:    r,
+		size: size,
+		opt:  opt.withDefaults(),
+	}
+
+	var buf [262]byte
+	n, _ := r.ReadAt(buf[:], 0)
+	switch {
+	case n >= 4 && string(buf[:4]) == string(RCCHeaderMagic[:]):
+		// a rcc file containing a stored zip file can look like a zip
+		return nil, fmt.Errorf("%w (rcc file)", ErrUnknownArchive)
+	case n >= 2 && buf[0] == 0x1f && buf[1] == 0x8b:
+		a.format = ArchiveTarGzip
+	case n >= 262 && string(buf[257:262]) == "ustar":
+		a.format = ArchiveTar
+	default:
+		zr, err := zip.NewReader(r, size)
+		if err != nil {
+			return nil, fmt.Errorf("%w (open zip: %v)", ErrUnknownArchive, err)
+		}
+		a.format, a.zip = ArchiveZip, zr
+	}
+	return a, nil
+}
+
+// Format returns the format of the archive.
+func (a *Archive) Format() ArchiveFormat {
+	return a.format
+}
+
+// Members returns the names of the regular files in the archive.
+func (a *Archive) Members() ([]string, error) {
+	var names []string
+	if err := a.each(func(f archiveFile) (bool, error) {
+		names = append(names, f.name)
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Open opens the regular file name in the archive. If it doesn't exist, an
+// error wrapping os.ErrNotExist is returned. If it needs to be decompressed and
+// is larger than ReaderOptions.MaxFileSize, an error wrapping ErrMaxFileSize is
+// returned.
+func (a *Archive) Open(name string) (*ArchiveMember, error) {
+	var m *ArchiveMember
+	if err := a.each(func(f archiveFile) (bool, error) {
+		if path.Clean(f.name) != path.Clean(name) {
+			return true, nil
+		}
+		var err error
+		m, err = a.open(f)
+		return false, err
+	}); err != nil {
+		return nil, fmt.Errorf("open %q: %w", name, err)
+	}
+	if m == nil {
+		return nil, fmt.Errorf("open %q: %w", name, os.ErrNotExist)
+	}
+	return m, nil
+}
+
+// Scan finds RCC files in the regular files in the archive (or only the ones fn
+// returns true for, if not nil) with ScanRCC. Members which need to be
+// decompressed and are larger than ReaderOptions.MaxFileSize are skipped. The
+// members containing RCC files must be closed when done. If an error occurs,
+// the RCC files found so far are returned with the error.
+func (a *Archive) Scan(fn func(name string) bool) ([]ArchiveRCC, error) {
+	var found []ArchiveRCC
+	err := a.each(func(f archiveFile) (bool, error) {
+		if fn != nil && !fn(f.name) {
+			return true, nil
+		}
+		if max := a.opt.MaxFileSize; f.sr == nil && max >= 0 && f.size > max {
+			return true, nil
+		}
+		m, err := a.open(f)
+		if err != nil {
+			return false, fmt.Errorf("scan %q: %w", f.name, err)
+		}
+		rs, err := ScanRCC(m, &a.opt)
+		for _, r := range rs {
+			found = append(found, ArchiveRCC{m, r})
+		}
+		if err != nil {
+			return false, fmt.Errorf("scan %q: %w", f.name, err)
+		}
+		if len(rs) == 0 {
+			return true, m.Close()
+		}
+		return true, nil
+	})
+	return found, err
+}
+
+// each calls fn for each regular file in the archive until it returns false or
+// an error.
+func (a *Archive) each(fn func(f archiveFile) (bool, error)) error {
+	if a.format == ArchiveZip {
+		for _, zf := range a.zip.File {
+			if !zf.Mode().IsRegular() {
+				continue
+			}
+			f := archiveFile{
+				name: zf.Name,
+				size: int64(zf.UncompressedSize64),
+				open: zf.Open,
+			}
+			if zf.Method == zip.Store {
+				off, err := zf.DataOffset()
+				if err != nil {
+					return fmt.Errorf("read zip header for %q: %w", zf.Name, err)
+				}
+				f.sr = io.NewSectionReader(a.r, off, int64(zf.CompressedSize64))
+			}
+			if cont, err := fn(f); err != nil || !cont {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		sr = io.NewSectionReader(a.r, 0, a.size)
+		tr *tar.Reader
+	)
+	if a.format == ArchiveTarGzip {
+		zr, err := gzip.NewReader(sr)
+		if err != nil {
+			return fmt.Errorf("open gzip reader: %w", err)
+		}
+		defer zr.Close()
+		tr = tar.NewReader(zr)
+	} else {
+		tr = tar.NewReader(sr) // this will seek past unread data
+	}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar header: %w", err)
+		}
+		if !h.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		f := archiveFile{
+			name: h.Name,
+			size: h.Size,
+			open: func() (io.ReadCloser, error) {
+				return ioutil.NopCloser(tr), nil
+			},
+		}
+		if a.format == ArchiveTar && !tarSparse(h) {
+			off, err := sr.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return fmt.Errorf("get offset of %q: %w", h.Name, err)
+			}
+			f.sr = io.NewSectionReader(a.r, off, h.Size)
+		}
+		if cont, err := fn(f); err != nil || !cont {
+			return err
+		}
+	}
+}
+
+// open opens f, reading it into memory or a temporary file if necessary.
+func (a *Archive) open(f archiveFile) (*ArchiveMember, error) {
+	m := &ArchiveMember{
+		name: f.name,
+		size: f.size,
+	}
+	if f.sr != nil {
+		m.r = f.sr
+		return m, nil
+	}
+	if max := a.opt.MaxFileSize; max >= 0 && f.size > max {
+		return nil, fmt.Errorf("%w (%d)", ErrMaxFileSize, max)
+	}
+	rc, err := f.open()
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	defer rc.Close()
+
+	ra, release, err := a.opt.spill(io.LimitReader(rc, f.size))
+	if err != nil {
+		return nil, err
+	}
+	if sz, _ := readerSize(ra); sz != f.size {
+		if release != nil {
+			release()
+		}
+		return nil, fmt.Errorf("decompress: %w (expected %d bytes, got %d)", io.ErrUnexpectedEOF, f.size, sz)
+	}
+	m.r, m.release = ra, release
+	return m, nil
+}
+
+// tarSparse checks whether the data for a tar entry isn't stored contiguously.
+func tarSparse(h *tar.Header) bool {
+	if h.Typeflag == tar.TypeGNUSparse {
+		return true
+	}
+	for k := range h.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the member in the archive.
+func (m *ArchiveMember) Name() string {
+	return m.name
+}
+
+// Size returns the uncompressed size of the member.
+func (m *ArchiveMember) Size() int64 {
+	return m.size
+}
+
+func (m *ArchiveMember) ReadAt(p []byte, off int64) (int, error) {
+	return m.r.ReadAt(p, off)
+}
+
+// Close removes the temporary file for the member, if any.
+func (m *ArchiveMember) Close() error {
+	if m.release == nil {
+		return nil
+	}
+	err := m.release()
+	m.release = nil
+	return err
+}
@@ -0,0 +1,172 @@
+package qrc
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	build := testBuildRCC(t)
+	rcc := build(CompressionNone, map[string][]byte{"a.txt": []byte("hello")})
+	lib := append(append(append([]byte("\x7fELF"), bytes.Repeat([]byte("x"), 1000)...), rcc...), bytes.Repeat([]byte("y"), 1000)...)
+
+	members := []struct {
+		name   string
+		data   []byte
+		stored bool
+	}{
+		{"assets/stored.rcc", rcc, true},
+		{"assets/deflated.rcc", rcc, false},
+		{"lib/arm64-v8a/libapp.so", lib, false},
+		{"res/text.txt", []byte("not an rcc"), false},
+	}
+
+	zipBuf := new(bytes.Buffer)
+	zw := zip.NewWriter(zipBuf)
+	if _, err := zw.Create("assets/"); err != nil {
+		t.Fatalf("create zip dir: %v", err)
+	}
+	for _, m := range members {
+		h := &zip.FileHeader{Name: m.name, Method: zip.Deflate}
+		if m.stored {
+			h.Method = zip.Store
+		}
+		w, err := zw.CreateHeader(h)
+		if err != nil {
+			t.Fatalf("create zip member: %v", err)
+		}
+		w.Write(m.data)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("write zip: %v", err)
+	}
+
+	tarBuf := new(bytes.Buffer)
+	tw := tar.NewWriter(tarBuf)
+	tw.WriteHeader(&tar.Header{Name: "assets/", Typeflag: tar.TypeDir, Mode: 0755})
+	for _, m := range members {
+		if err := tw.WriteHeader(&tar.Header{Name: "./" + m.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(m.data))}); err != nil {
+			t.Fatalf("write tar header: %v", err)
+		}
+		tw.Write(m.data)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("write tar: %v", err)
+	}
+
+	tgzBuf := new(bytes.Buffer)
+	gw := gzip.NewWriter(tgzBuf)
+	gw.Write(tarBuf.Bytes())
+	if err := gw.Close(); err != nil {
+		t.Fatalf("write tar.gz: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "qrc")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, c := range []struct {
+		what   string
+		buf    []byte
+		format ArchiveFormat
+		opt    ReaderOptions
+		direct bool // stored members are read directly
+	}{
+		{"zip", zipBuf.Bytes(), ArchiveZip, ReaderOptions{}, true},
+		{"zip spill", zipBuf.Bytes(), ArchiveZip, ReaderOptions{SpillSize: 100, SpillDir: dir}, true},
+		{"tar", tarBuf.Bytes(), ArchiveTar, ReaderOptions{}, true},
+		{"tar.gz", tgzBuf.Bytes(), ArchiveTarGzip, ReaderOptions{}, false},
+		{"tar.gz spill", tgzBuf.Bytes(), ArchiveTarGzip, ReaderOptions{SpillSize: 100, SpillDir: dir}, false},
+	} {
+		opt := c.opt
+		a, err := OpenArchive(bytes.NewReader(c.buf), int64(len(c.buf)), &opt)
+		if err != nil {
+			t.Fatalf("%s: open: %v", c.what, err)
+		}
+		if a.Format() != c.format {
+			t.Errorf("%s: expected format %s, got %s", c.what, c.format, a.Format())
+		}
+
+		if names, err := a.Members(); err != nil {
+			t.Errorf("%s: members: unexpected error: %v", c.what, err)
+		} else if len(names) != len(members) {
+			t.Errorf("%s: members: expected %d members, got %q", c.what, len(members), names)
+		}
+
+		for _, x := range members {
+			m, err := a.Open(x.name)
+			if err != nil {
+				t.Fatalf("%s: open %q: %v", c.what, x.name, err)
+			}
+			if _, ok := m.r.(*io.SectionReader); ok != (c.direct && x.stored || c.format == ArchiveTar) {
+				t.Errorf("%s: open %q: unexpected reader %T", c.what, x.name, m.r)
+			}
+			if _, ok := m.r.(*os.File); ok != (c.opt.SpillSize != 0 && len(x.data) > 100 && !(x.stored && c.direct)) {
+				t.Errorf("%s: open %q: unexpected reader %T", c.what, x.name, m.r)
+			}
+			if buf, err := ioutil.ReadAll(io.NewSectionReader(m, 0, m.Size())); err != nil {
+				t.Errorf("%s: read %q: unexpected error: %v", c.what, x.name, err)
+			} else if !bytes.Equal(buf, x.data) {
+				t.Errorf("%s: read %q: incorrect data", c.what, x.name)
+			}
+			if err := m.Close(); err != nil {
+				t.Errorf("%s: close %q: unexpected error: %v", c.what, x.name, err)
+			}
+		}
+		if _, err := a.Open("assets/missing.rcc"); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s: open missing: expected os.ErrNotExist, got %v", c.what, err)
+		}
+
+		found, err := a.Scan(nil)
+		if err != nil {
+			t.Fatalf("%s: scan: %v", c.what, err)
+		}
+		if len(found) != 3 {
+			t.Fatalf("%s: scan: expected 3 rcc files, got %d", c.what, len(found))
+		}
+		for _, f := range found {
+			switch f.Member.Name() {
+			case "assets/stored.rcc", "assets/deflated.rcc", "./assets/stored.rcc", "./assets/deflated.rcc":
+				if f.Offset != 0 {
+					t.Errorf("%s: scan %q: expected offset 0, got %d", c.what, f.Member.Name(), f.Offset)
+				}
+			default:
+				if f.Offset != 1004 {
+					t.Errorf("%s: scan %q: expected offset 1004, got %d", c.what, f.Member.Name(), f.Offset)
+				}
+			}
+			testFilesEqual(t, f.Reader, map[string]string{
+				"a.txt": "hello",
+			})
+			if err := f.Member.Close(); err != nil {
+				t.Errorf("%s: close %q: unexpected error: %v", c.what, f.Member.Name(), err)
+			}
+		}
+		if fs, _ := ioutil.ReadDir(dir); len(fs) != 0 {
+			t.Errorf("%s: expected temp files to be removed, got %d files", c.what, len(fs))
+		}
+
+		if found, err := a.Scan(func(name string) bool { return false }); err != nil || len(found) != 0 {
+			t.Errorf("%s: scan nothing: expected nothing, got %d, %v", c.what, len(found), err)
+		}
+	}
+
+	if _, err := OpenArchive(bytes.NewReader(rcc), int64(len(rcc)), nil); !errors.Is(err, ErrUnknownArchive) {
+		t.Errorf("rcc: expected ErrUnknownArchive, got %v", err)
+	}
+
+	// a stored zip at the end of a rcc file shouldn't make it look like a zip
+	zrcc := build(CompressionNone, map[string][]byte{"z.zip": zipBuf.Bytes()})
+	if _, err := OpenArchive(bytes.NewReader(zrcc), int64(len(zrcc)), nil); !errors.Is(err, ErrUnknownArchive) {
+		t.Errorf("rcc with zip: expected ErrUnknownArchive, got %v", err)
+	}
+}
@@ -3,8 +3,10 @@ package main
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -19,8 +21,12 @@ type QRC2Zip struct {
 	Output    string
 	Force     bool
 	Recursive bool
+	Sniff     bool
 	Exclude   []string
 	Verbose   bool
+	Verify    bool
+	SpillSize int64
+	Member    string
 }
 
 func main() {
@@ -31,8 +37,12 @@ func main() {
 	pflag.StringVarP(&q2z.Output, "output", "o", "resources.zip", "Output filename")
 	pflag.BoolVarP(&q2z.Force, "force", "f", false, "Ignore errors during extraction if possible")
 	pflag.BoolVarP(&q2z.Recursive, "recursive", "r", false, "Expand nested RCC files")
+	pflag.BoolVar(&q2z.Sniff, "sniff", false, "With --recursive, also expand files without the .rcc extension which start with the RCC magic")
 	pflag.StringArrayVarP(&q2z.Exclude, "exclude", "e", nil, "Exclude files matching this glob (can be specified multiple times)")
 	pflag.BoolVarP(&q2z.Verbose, "verbose", "v", false, "Show information about the files being extracted")
+	pflag.BoolVar(&q2z.Verify, "verify", false, "Check the structure of the resources before extracting")
+	pflag.Int64Var(&q2z.SpillSize, "spill-size", 64, "Decompress nested RCC files and archive members larger than this many MiB to a temp file (0 to disable)")
+	pflag.StringVar(&q2z.Member, "member", "", "If the input is an archive, only read RCC files from this member instead of scanning all of them")
 	pflag.BoolVarP(&help, "help", "h", false, "Show this help text")
 	pflag.Parse()
 
@@ -46,6 +56,17 @@ func main() {
 			"  To find executable offsets and format version, look for calls to qRegisterResourceData. These\n"+
 			"  are usually within entry points or qInitResource* functions. qRegisterResourceData takes four\n"+
 			"  arguments: format, tree, names, data.\n"+
+			"\nStreaming:\n"+
+			"  If rcc_file is '-', the RCC file is read from stdin in a single pass. The data is buffered in\n"+
+			"  memory (or a temp file, see --spill-size) if it is before the tree or names (which is the case\n"+
+			"  for files written by rcc). Archives are not supported, and --verify can't be used.\n"+
+			"\nArchives:\n"+
+			"  If rcc_file is a zip (including APKs), tar, or tar.gz archive, each member (or the one specified\n"+
+			"  by --member) is scanned for RCC files, including ones embedded in other files like Qt libraries.\n"+
+			"  The resources from each RCC file are written to a directory named after the member, with\n"+
+			"  '@0xOFFSET' appended if the RCC file doesn't start at the beginning of the member. If --member\n"+
+			"  is used and it contains a single RCC file, the resources are written to the root instead.\n"+
+			"  Uncompressed nested RCC files are only expanded with --recursive.\n"+
 			"\nQt support:\n"+
 			"  Format versions 1-3 are supported, along with locale/country codes from Qt 5.13. Resources\n"+
 			"  can be compressed with zlib or zstd.\n"+
@@ -69,7 +90,11 @@ func main() {
 	var err error
 	switch pflag.NArg() {
 	case 1:
-		err = q2z.DoRCC(pflag.Args()[0])
+		if pflag.Args()[0] == "-" {
+			err = q2z.DoStream(os.Stdin)
+		} else {
+			err = q2z.DoRCC(pflag.Args()[0])
+		}
 	case 5:
 		var formatVersion int
 		var treeOffset, dataOffset, namesOffset int64
@@ -118,12 +143,120 @@ func (q2z QRC2Zip) DoRCC(rcc string) error {
 	}
 	defer f.Close()
 
-	r, err := qrc.NewReaderFromRCC(f)
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat rcc file: %w", err)
+	}
+
+	// try the rcc first since a rcc file containing a zip can look like one
+	var rerr error
+	if q2z.Member == "" {
+		r, err := qrc.NewReaderFromRCCWithOptions(f, q2z.options())
+		if err == nil {
+			return q2z.doReaders([]string{""}, []*qrc.Reader{r})
+		}
+		if !errors.Is(err, qrc.ErrBadMagic) {
+			return fmt.Errorf("parse rcc file %q: %w", rcc, err)
+		}
+		rerr = err
+	}
+
+	a, err := qrc.OpenArchive(f, fi.Size(), q2z.options())
+	if err != nil {
+		if rerr != nil {
+			return fmt.Errorf("parse rcc file %q: %w", rcc, rerr)
+		}
+		return fmt.Errorf("open archive %q: %w", rcc, err)
+	}
+	return q2z.doArchive(a)
+}
+
+func (q2z QRC2Zip) DoStream(in io.Reader) error {
+	if q2z.Verify {
+		return errors.New("verify is not supported when reading from stdin")
+	}
+
+	s, err := qrc.NewStreamReader(in, q2z.options())
 	if err != nil {
-		return fmt.Errorf("parse rcc file %q: %w", rcc, err)
+		return fmt.Errorf("parse rcc stream: %w", err)
 	}
+	defer s.Close()
 
-	return q2z.doReader(r)
+	return q2z.output(func(zw *zip.Writer) error {
+		return q2z.generateStream(zw, s)
+	})
+}
+
+func (q2z QRC2Zip) doArchive(a *qrc.Archive) error {
+	var found []qrc.ArchiveRCC
+	if q2z.Member != "" {
+		m, err := a.Open(q2z.Member)
+		if err != nil {
+			return fmt.Errorf("open %s member: %w", a.Format(), err)
+		}
+		defer m.Close()
+
+		rs, err := qrc.ScanRCC(m, q2z.options())
+		if err != nil {
+			return fmt.Errorf("scan %s member %q: %w", a.Format(), q2z.Member, err)
+		}
+		for _, r := range rs {
+			found = append(found, qrc.ArchiveRCC{Member: m, EmbeddedRCC: r})
+		}
+	} else {
+		var err error
+		found, err = a.Scan(nil)
+		for _, x := range found {
+			defer x.Member.Close() // closing a member more than once is fine
+		}
+		if err != nil {
+			return fmt.Errorf("scan %s members: %w", a.Format(), err)
+		}
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("no rcc files found in %s", a.Format())
+	}
+
+	// skip stored nested rcc files, which are also found by the scan
+	nested := map[*qrc.ArchiveMember]map[int64]bool{}
+	for _, x := range found {
+		if nested[x.Member] == nil {
+			nested[x.Member] = map[int64]bool{}
+		}
+		x.Reader.Walk(func(_ string, entry *qrc.ReaderEntry, err error) error {
+			if err == nil && !entry.IsDir() && entry.Compression() == qrc.CompressionNone {
+				nested[x.Member][entry.Offset()] = true
+			}
+			return nil
+		}, false)
+	}
+
+	var prefixes []string
+	var rs []*qrc.Reader
+	for _, x := range found {
+		name := strings.TrimLeft(path.Clean(x.Member.Name()), "/")
+		if nested[x.Member][x.Offset] {
+			if q2z.Verbose {
+				fmt.Printf("SKIP    %q (nested rcc at 0x%X)\n", name, x.Offset)
+			}
+			continue
+		}
+		var prefix string
+		if x.Offset != 0 {
+			prefix = fmt.Sprintf("%s@0x%X", name, x.Offset)
+		} else {
+			prefix = name
+		}
+		if q2z.Verbose {
+			fmt.Printf("RCC     %q (0x%X in %q)\n", prefix, x.Offset, x.Member.Name())
+		}
+		prefixes = append(prefixes, prefix)
+		rs = append(rs, x.Reader)
+	}
+	if q2z.Member != "" && len(rs) == 1 {
+		prefixes[0] = ""
+	}
+	return q2z.doReaders(prefixes, rs)
 }
 
 func (q2z QRC2Zip) DoRaw(file string, formatVersion int, treeOffset, dataOffset, namesOffset int64) error {
@@ -133,15 +266,46 @@ func (q2z QRC2Zip) DoRaw(file string, formatVersion int, treeOffset, dataOffset,
 	}
 	defer f.Close()
 
-	r, err := qrc.NewReader(f, formatVersion, treeOffset, dataOffset, namesOffset)
+	r, err := qrc.NewReaderWithOptions(f, formatVersion, treeOffset, dataOffset, namesOffset, q2z.options())
 	if err != nil {
 		return fmt.Errorf("parse rcc file %q: %w", file, err)
 	}
 
-	return q2z.doReader(r)
+	return q2z.doReaders([]string{""}, []*qrc.Reader{r})
+}
+
+// doReaders writes the resources from each reader into the output zip under
+// the corresponding prefix.
+func (q2z QRC2Zip) doReaders(prefixes []string, rs []*qrc.Reader) error {
+	if q2z.Verify {
+		var n int
+		for i, r := range rs {
+			for _, x := range r.Verify() {
+				if prefixes[i] != "" {
+					fmt.Fprintf(os.Stderr, "Warning: verify: %s: %v\n", prefixes[i], x)
+				} else {
+					fmt.Fprintf(os.Stderr, "Warning: verify: %v\n", x)
+				}
+				n++
+			}
+		}
+		if n != 0 && !q2z.Force {
+			return fmt.Errorf("verify: found %d problems", n)
+		}
+	}
+
+	return q2z.output(func(zw *zip.Writer) error {
+		for i, r := range rs {
+			if err := q2z.generate(zw, prefixes[i], r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (q2z QRC2Zip) doReader(r *qrc.Reader) error {
+// output writes the zip generated by fn to the output file.
+func (q2z QRC2Zip) output(fn func(zw *zip.Writer) error) error {
 	fon := "." + q2z.Output + ".tmp"
 	defer os.Remove(fon)
 
@@ -152,7 +316,7 @@ func (q2z QRC2Zip) doReader(r *qrc.Reader) error {
 	defer fo.Close()
 
 	zw := zip.NewWriter(fo)
-	if err := q2z.generate(zw, r); err != nil {
+	if err := fn(zw); err != nil {
 		return fmt.Errorf("generate zip: %w", err)
 	}
 	if err = zw.Close(); err != nil {
@@ -170,82 +334,187 @@ func (q2z QRC2Zip) doReader(r *qrc.Reader) error {
 	return nil
 }
 
-func (q2z QRC2Zip) generate(w *zip.Writer, r *qrc.Reader) error {
+func (q2z QRC2Zip) options() *qrc.ReaderOptions {
+	spill := q2z.SpillSize << 20
+	if spill <= 0 {
+		spill = -1 // zero is the default for StreamReader
+	}
+	return &qrc.ReaderOptions{
+		Preload:     true,
+		SpillSize:   spill,
+		NestedSniff: q2z.Sniff,
+	}
+}
+
+func (q2z QRC2Zip) generate(w *zip.Writer, prefix string, r *qrc.Reader) error {
 	return r.Walk(func(rpath string, entry *qrc.ReaderEntry, err error) error {
-		for _, p := range q2z.Exclude {
-			if m, err := path.Match(p, rpath); err != nil {
-				return fmt.Errorf("check for match against skip pattern %q: %w", p, err)
-			} else if m {
-				if q2z.Verbose {
-					fmt.Printf("SKIP    %q (matches %q)\n", rpath, p)
-				}
-				return filepath.SkipDir
-			}
+		if prefix != "" {
+			rpath = prefix + "/" + rpath
 		}
-		var offset, size int64
-		if err == nil && entry != nil {
-			offset = entry.Offset()
-			size, err = entry.Size()
+		return q2z.entry(w, rpath, entry, err, func() (io.ReadCloser, error) {
+			return entry.Open()
+		})
+	}, q2z.Recursive)
+}
+
+func (q2z QRC2Zip) generateStream(w *zip.Writer, s *qrc.StreamReader) error {
+	var skip []string // excluded dirs
+next:
+	for {
+		entry, rpath, err := s.Next()
+		if err == io.EOF {
+			return nil
 		}
 		if err != nil {
-			if q2z.Verbose {
-				fmt.Printf("ERROR  %q (%v)\n", rpath, err)
+			return err
+		}
+		for _, p := range skip {
+			if strings.HasPrefix(rpath, p) {
+				continue next
 			}
-			if q2z.Force {
-				fmt.Fprintf(os.Stderr, "Warning: ignoring error: walk %q: %v\n", rpath, err)
-				return nil
+		}
+		if x, err := q2z.excluded(rpath); err != nil {
+			return err
+		} else if x {
+			skip = append(skip, rpath+"/")
+			continue
+		}
+
+		// nested rcc files have to be opened now since the data can't be read later
+		if q2z.Recursive {
+			r, err := entry.OpenNestedRCC(rpath)
+			if err != nil {
+				if q2z.Force {
+					fmt.Fprintf(os.Stderr, "Warning: ignoring error: open nested rcc %q: %v\n", rpath, err)
+					continue
+				}
+				return fmt.Errorf("open nested rcc %q: %w", rpath, err)
+			}
+			if r != nil {
+				err = q2z.generate(w, rpath, r)
+				r.Close()
+				if err != nil {
+					return err
+				}
+				continue
 			}
+		}
+
+		if err := q2z.entry(w, rpath, entry, nil, func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(s), nil
+		}); err != nil {
 			return err
 		}
-		if entry.IsDir() {
+	}
+}
+
+// excluded checks whether rpath matches an exclude pattern.
+func (q2z QRC2Zip) excluded(rpath string) (bool, error) {
+	for _, p := range q2z.Exclude {
+		if m, err := path.Match(p, rpath); err != nil {
+			return false, fmt.Errorf("check for match against skip pattern %q: %w", p, err)
+		} else if m {
 			if q2z.Verbose {
-				fmt.Printf("DIR     %q (0x%X + %d)\n", rpath, offset, size)
+				fmt.Printf("SKIP    %q (matches %q)\n", rpath, p)
 			}
-			return nil
+			return true, nil
 		}
+	}
+	return false, nil
+}
 
-		var c string
-		x, y := entry.Constraints()
-		if x != qrc.CountryAnyCountry {
-			c += "[country!" + x.String() + "]"
+// entry writes an entry to the zip, opening the contents of files with open.
+// If it is excluded, filepath.SkipDir is returned.
+func (q2z QRC2Zip) entry(w *zip.Writer, rpath string, entry *qrc.ReaderEntry, err error, open func() (io.ReadCloser, error)) error {
+	if x, err := q2z.excluded(rpath); err != nil {
+		return err
+	} else if x {
+		return filepath.SkipDir
+	}
+	var offset string
+	var size int64
+	if err == nil && entry != nil {
+		offset = entryOffset(entry)
+		size, err = entry.Size()
+	}
+	if err != nil {
+		if q2z.Verbose {
+			fmt.Printf("ERROR  %q (%v)\n", rpath, err)
 		}
-		if y != qrc.LanguageAnyLanguage && y != qrc.LanguageC {
-			c += "[language!" + y.String() + "]"
+		if q2z.Force {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring error: walk %q: %v\n", rpath, err)
+			return nil
 		}
-
-		var f string
-		if c != "" {
-			f = strings.TrimSuffix(rpath, filepath.Ext(rpath)) + c + filepath.Ext(rpath)
-		} else {
-			f = rpath
+		return err
+	}
+	if entry.IsDir() {
+		if q2z.Verbose {
+			fmt.Printf("DIR     %q (%s + %d)\n", rpath, offset, size)
 		}
+		return nil
+	}
 
-		if q2z.Verbose {
-			if rpath != f {
-				fmt.Printf("FILE    %q => %q (0x%X + %d)\n", rpath, f, offset, size)
+	x, y := entry.Constraints()
+	f := qrc.FormatConstraints(rpath, x, y)
+
+	if q2z.Verbose {
+		var c string
+		if entry.Compression() != qrc.CompressionNone {
+			if usize, err := entry.UncompressedSize(); err == nil {
+				c = fmt.Sprintf(", %s %d", entry.Compression(), usize)
 			} else {
-				fmt.Printf("FILE    %q (0x%X + %d)\n", rpath, offset, size)
+				c = fmt.Sprintf(", %s", entry.Compression())
 			}
 		}
-
-		d, err := entry.Open()
-		if err != nil {
-			return fmt.Errorf("open resource %q %s: %w", rpath, c, err)
+		if rpath != f {
+			fmt.Printf("FILE    %q => %q (%s + %d%s)\n", rpath, f, offset, size, c)
+		} else {
+			fmt.Printf("FILE    %q (%s + %d%s)\n", rpath, offset, size, c)
 		}
-		defer d.Close()
+	}
 
-		z, err := w.CreateHeader(&zip.FileHeader{
-			Name:     f, // will already be separated with slashes
-			Modified: entry.ModTime(),
-		})
-		if err != nil {
-			return fmt.Errorf("create zip header for %q: %w", f, err)
+	d, err := open()
+	if err != nil {
+		if q2z.Force && recoverable(err) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring error: open resource %q: %v\n", f, err)
+			return nil
 		}
+		return fmt.Errorf("open resource %q: %w", f, err)
+	}
+	defer d.Close()
+
+	z, err := w.CreateHeader(&zip.FileHeader{
+		Name:     f, // will already be separated with slashes
+		Modified: entry.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("create zip header for %q: %w", f, err)
+	}
 
-		if _, err := io.Copy(z, d); err != nil {
-			return fmt.Errorf("write contents of %q: %w", f, err)
+	if _, err := io.Copy(z, d); err != nil {
+		if q2z.Force && recoverable(err) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring error: write contents of %q (output will be truncated): %v\n", f, err)
+			return nil
 		}
+		return fmt.Errorf("write contents of %q: %w", f, err)
+	}
 
-		return nil
-	}, q2z.Recursive)
+	return nil
+}
+
+// entryOffset formats the offset of an entry relative to the input file, or if
+// it is inside a compressed nested RCC file, relative to the nested RCC file it
+// was read from.
+func entryOffset(entry *qrc.ReaderEntry) string {
+	if off, ok := entry.AbsOffset(); ok {
+		return fmt.Sprintf("0x%X", off)
+	}
+	return fmt.Sprintf("0x%X in %q", entry.Offset(), entry.Reader().Parent().Path())
+}
+
+// recoverable checks if err is caused by an invalid resource which can be
+// skipped without affecting other ones.
+func recoverable(err error) bool {
+	var qe *qrc.Error
+	return errors.As(err, &qe) && !errors.Is(err, qrc.ErrMaxTotalSize)
 }
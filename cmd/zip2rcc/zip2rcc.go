@@ -0,0 +1,186 @@
+// Command zip2rcc converts a zip file created by qrc2zip back into a RCC file.
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pgaskin/qrc"
+	"github.com/spf13/pflag"
+)
+
+type Zip2RCC struct {
+	Output        string
+	FormatVersion int
+	Deduplicate   bool
+	Verbose       bool
+	Compress      int
+	Threshold     int
+	CompressAlgo  string
+	NoCompress    bool
+	Generator     string
+	Name          string
+	NoNamespace   bool
+	PythonStyle   string
+}
+
+func main() {
+	var z2r Zip2RCC
+	var help bool
+
+	pflag.CommandLine.SortFlags = false
+	pflag.StringVarP(&z2r.Output, "output", "o", "resources.rcc", "Output filename")
+	pflag.IntVarP(&z2r.FormatVersion, "format-version", "F", 2, "Qt resource format version (1-3)")
+	pflag.BoolVarP(&z2r.Deduplicate, "deduplicate", "d", false, "Store files with identical contents once")
+	pflag.BoolVarP(&z2r.Verbose, "verbose", "v", false, "Show information about the resources being written")
+	pflag.IntVar(&z2r.Compress, "compress", -1, "Compression level (-1 for the default)")
+	pflag.IntVar(&z2r.Threshold, "threshold", 70, "Minimum percentage of the file size which must be saved to use compression")
+	pflag.StringVar(&z2r.CompressAlgo, "compress-algo", "zlib", "Compression algorithm (zlib, zstd, best, none)")
+	pflag.BoolVar(&z2r.NoCompress, "no-compress", false, "Disable compression")
+	pflag.StringVarP(&z2r.Generator, "generator", "g", "rcc", "Output type (rcc, cpp, python)")
+	pflag.StringVar(&z2r.Name, "name", "", "Resource name for generated code")
+	pflag.BoolVar(&z2r.NoNamespace, "no-namespace", false, "Disable QT_NAMESPACE support in generated code")
+	pflag.StringVar(&z2r.PythonStyle, "python-style", "PyQt5", "Qt binding for generated Python code (PyQt5, PyQt6, PySide6)")
+	pflag.BoolVarP(&help, "help", "h", false, "Show this help text")
+	pflag.Parse()
+
+	if help || pflag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, ""+
+			"Usage: %s [options] zip_file\n"+
+			"\nOptions:\n"+
+			"%s"+
+			"\nInput:\n"+
+			"  The zip file should be in the format written by qrc2zip. If a filename has language/country\n"+
+			"  constraints in the format '[language!LanguageName]' and '[country!CountryName]' before the\n"+
+			"  extension, they are removed and set on the resource. Modification times are preserved if the\n"+
+			"  format version is >= 2.\n"+
+			"\nCompression:\n"+
+			"  The compression options behave the same as the ones for rcc, so files are compressed with\n"+
+			"  zlib at the default level unless --no-compress is used. Note that zstd compression requires\n"+
+			"  format version 3.\n"+
+			"\ngithub.com/pgaskin/qrc\n",
+			os.Args[0], pflag.CommandLine.FlagUsages(),
+		)
+		if help {
+			os.Exit(0)
+		} else {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if err := z2r.Do(pflag.Args()[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(1)
+		return
+	}
+
+	os.Exit(0)
+	return
+}
+
+func (z2r Zip2RCC) Do(file string) error {
+	var ps qrc.PythonStyle
+	switch z2r.Generator {
+	case "rcc", "cpp":
+	case "python":
+		switch z2r.PythonStyle {
+		case qrc.PythonPyQt5.String():
+			ps = qrc.PythonPyQt5
+		case qrc.PythonPyQt6.String():
+			ps = qrc.PythonPyQt6
+		case qrc.PythonPySide6.String():
+			ps = qrc.PythonPySide6
+		default:
+			return fmt.Errorf("unknown python style %q", z2r.PythonStyle)
+		}
+	default:
+		return fmt.Errorf("unknown generator %q", z2r.Generator)
+	}
+
+	c, err := qrc.ParseCompression(z2r.CompressAlgo)
+	if err != nil {
+		return err
+	}
+	if z2r.NoCompress {
+		c = qrc.CompressionNone
+	}
+
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return fmt.Errorf("open zip file: %w", err)
+	}
+	defer zr.Close()
+
+	w, err := qrc.NewWriterFromZip(&zr.Reader)
+	if err != nil {
+		return fmt.Errorf("read zip file %q: %w", file, err)
+	}
+
+	if z2r.Deduplicate {
+		saved, err := w.Deduplicate()
+		if err != nil {
+			return fmt.Errorf("deduplicate: %w", err)
+		}
+		if z2r.Verbose {
+			fmt.Printf("DEDUP   saved %d bytes\n", saved)
+		}
+	}
+
+	level := z2r.Compress
+	switch {
+	case level < 0:
+		level = qrc.CompressionLevelDefault
+	case level == 0:
+		level = qrc.CompressionLevelStore
+	}
+	if err := w.Compress(qrc.CompressionPolicy{
+		CompressionSettings: qrc.CompressionSettings{
+			Compression: c,
+			Level:       level,
+			Threshold:   z2r.Threshold,
+		},
+	}); err != nil {
+		return fmt.Errorf("compress: %w", err)
+	}
+
+	fon := filepath.Join(filepath.Dir(z2r.Output), "."+filepath.Base(z2r.Output)+".tmp")
+	defer os.Remove(fon)
+
+	fo, err := os.OpenFile(fon, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("create output temp file: %w", err)
+	}
+	defer fo.Close()
+
+	switch z2r.Generator {
+	case "rcc":
+		err = w.WriteRCC(fo, z2r.FormatVersion)
+	case "cpp":
+		err = w.WriteCPP(fo, z2r.FormatVersion, qrc.CPPOptions{
+			Name:        z2r.Name,
+			NoNamespace: z2r.NoNamespace,
+		})
+	case "python":
+		err = w.WritePython(fo, z2r.FormatVersion, qrc.PythonOptions{
+			Style: ps,
+		})
+	default:
+		panic("unexpected generator")
+	}
+	if err != nil {
+		return fmt.Errorf("generate %s: %w", z2r.Generator, err)
+	}
+
+	if err := fo.Close(); err != nil {
+		return fmt.Errorf("close output: %w", err)
+	}
+
+	if err := os.Rename(fon, z2r.Output); err != nil {
+		return fmt.Errorf("rename temp file %q to output file %q: %w", fon, z2r.Output, err)
+	}
+
+	return nil
+}
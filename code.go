@@ -0,0 +1,131 @@
+package qrc
+
+import (
+	"bufio"
+	"strconv"
+)
+
+// codeWriter writes the tree, names, and data as source code in the same
+// format as rcc. Write errors are returned when flushing the bufio.Writer.
+type codeWriter struct {
+	w      *bufio.Writer
+	python bool // write the contents of a Python bytes literal instead of C++ array items
+}
+
+func (c *codeWriter) str(s string) {
+	c.w.WriteString(s)
+}
+
+// comment writes a comment and starts a new line (C++ only).
+func (c *codeWriter) comment(s string) {
+	if !c.python {
+		c.str("  // " + s + "\n  ")
+	}
+}
+
+// sep starts a new indented line.
+func (c *codeWriter) sep() {
+	if c.python {
+		c.str("\\\n")
+	} else {
+		c.str("\n  ")
+	}
+}
+
+// nl starts a new unindented line.
+func (c *codeWriter) nl() {
+	if c.python {
+		c.str("\\\n")
+	} else {
+		c.str("\n")
+	}
+}
+
+func (c *codeWriter) hex(b byte) {
+	const digits = "0123456789abcdef"
+	if c.python {
+		c.w.WriteString("\\x")
+		c.w.WriteByte(digits[b>>4])
+		c.w.WriteByte(digits[b&0xf])
+		return
+	}
+	c.w.WriteString("0x")
+	if b >= 16 {
+		c.w.WriteByte(digits[b>>4])
+	}
+	c.w.WriteByte(digits[b&0xf])
+	c.w.WriteByte(',')
+}
+
+func (c *codeWriter) uint16(v uint16) {
+	c.hex(byte(v >> 8))
+	c.hex(byte(v))
+}
+
+func (c *codeWriter) uint32(v uint32) {
+	c.uint16(uint16(v >> 16))
+	c.uint16(uint16(v))
+}
+
+func (c *codeWriter) uint64(v uint64) {
+	c.uint32(uint32(v >> 32))
+	c.uint32(uint32(v))
+}
+
+func (c *codeWriter) data(l *writerLayout) {
+	for _, d := range l.data {
+		c.comment(d.path)
+		c.uint32(uint32(len(d.blob.data)))
+		c.sep()
+		for i, b := range d.blob.data {
+			c.hex(b)
+			if i%16 == 0 {
+				c.sep()
+			}
+		}
+		c.sep()
+	}
+}
+
+func (c *codeWriter) names(l *writerLayout) {
+	for _, n := range l.names {
+		c.comment(n.name)
+		c.uint16(uint16(len(n.u16)))
+		c.sep()
+		c.uint32(n.hash)
+		c.sep()
+		for i, x := range n.u16 {
+			c.uint16(x)
+			if i%16 == 0 {
+				c.sep()
+			}
+		}
+		c.sep()
+	}
+}
+
+func (c *codeWriter) tree(l *writerLayout) {
+	for _, n := range l.tree {
+		if n.Language != LanguageC && !n.IsDir() {
+			// note: the second '[' is a typo in rcc
+			c.comment(":" + n.path + " [" + strconv.Itoa(int(n.Country)) + "::" + strconv.Itoa(int(n.Language)) + "[")
+		} else {
+			c.comment(":" + n.path)
+		}
+		c.uint32(n.NameOffset)
+		c.uint16(uint16(n.Flags))
+		if n.IsDir() {
+			c.uint32(n.ChildCount)
+			c.uint32(n.ChildOffset)
+		} else {
+			c.uint16(uint16(n.Country))
+			c.uint16(uint16(n.Language))
+			c.uint32(n.DataOffset)
+		}
+		c.nl()
+		if n.Format >= 2 {
+			c.uint64(n.Modified)
+			c.nl()
+		}
+	}
+}
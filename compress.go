@@ -0,0 +1,278 @@
+package qrc
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is a compression algorithm for resource data.
+type Compression int
+
+const (
+	CompressionNone Compression = iota
+	CompressionZlib
+	CompressionZstd
+	CompressionBest // zlib or zstd at the highest level, whichever is smaller
+)
+
+// ParseCompression parses a compression algorithm in the format accepted by
+// rcc's -compress-algo option.
+func ParseCompression(s string) (Compression, error) {
+	switch s {
+	case "none":
+		return CompressionNone, nil
+	case "zlib":
+		return CompressionZlib, nil
+	case "zstd":
+		return CompressionZstd, nil
+	case "best":
+		return CompressionBest, nil
+	}
+	return 0, fmt.Errorf("unknown compression algorithm %q", s)
+}
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionZlib:
+		return "zlib"
+	case CompressionZstd:
+		return "zstd"
+	case CompressionBest:
+		return "best"
+	}
+	return fmt.Sprintf("Compression(%d)", int(c))
+}
+
+// Flag returns the NodeFlag for the compression algorithm. CompressionBest
+// doesn't have a flag.
+func (c Compression) Flag() NodeFlag {
+	switch c {
+	case CompressionZlib:
+		return NodeFlagCompressed
+	case CompressionZstd:
+		return NodeFlagCompressedZstd
+	}
+	return NodeFlagNone
+}
+
+// Compression levels with a special meaning.
+const (
+	CompressionLevelDefault = 0  // the default level for the algorithm
+	CompressionLevelStore   = -1 // zlib without compressing the data (rcc's -compress 0)
+)
+
+// CompressionSettings controls how a file is compressed. The zero value
+// stores files uncompressed.
+type CompressionSettings struct {
+	// Compression is the algorithm to use (rcc's -compress-algo). Using
+	// CompressionNone is equivalent to rcc's -no-compress.
+	Compression Compression
+
+	// Level is the compression level (rcc's -compress), or zero for the
+	// default. CompressionLevelStore stores zlib data without compressing it,
+	// and is the same as the default for zstd. For zstd, the level is mapped
+	// to the closest one supported by the encoder, so the output will not
+	// exactly match libzstd. For CompressionBest, the level is used for both
+	// algorithms, or the highest one if it is the default.
+	Level int
+
+	// Threshold is the minimum percentage of the original size which must be
+	// saved for the compressed data to be kept (rcc's -threshold).
+	Threshold int
+}
+
+// CompressionOverride overrides the settings for files with a path matching
+// Pattern (a path.Match glob). This is similar to the compress,
+// compression-algorithm, and threshold attributes in a .qrc file.
+type CompressionOverride struct {
+	Pattern string
+	CompressionSettings
+}
+
+// CompressionPolicy decides how each file in a Writer is compressed, like rcc.
+type CompressionPolicy struct {
+	CompressionSettings
+
+	// Overrides are checked in order, and the first match is used instead of
+	// the default settings.
+	Overrides []CompressionOverride
+}
+
+// DefaultCompressionPolicy returns the same policy as rcc's defaults (zlib at
+// the default level with a threshold of 70%).
+func DefaultCompressionPolicy() CompressionPolicy {
+	return CompressionPolicy{
+		CompressionSettings: CompressionSettings{
+			Compression: CompressionZlib,
+			Threshold:   70,
+		},
+	}
+}
+
+// Settings returns the compression settings for the specified path.
+func (p CompressionPolicy) Settings(rpath string) (CompressionSettings, error) {
+	for _, o := range p.Overrides {
+		if m, err := path.Match(o.Pattern, rpath); err != nil {
+			return CompressionSettings{}, fmt.Errorf("match override pattern %q: %w", o.Pattern, err)
+		} else if m {
+			return o.CompressionSettings, nil
+		}
+	}
+	return p.CompressionSettings, nil
+}
+
+// Compress decompresses every file and compresses it again according to the
+// policy. Files which are shared (see Writer.Deduplicate) are only compressed
+// once, using the settings for the first path.
+func (w *Writer) Compress(p CompressionPolicy) error {
+	return w.recompress(func(path string, _ *writerBlob) (CompressionSettings, bool, error) {
+		s, err := p.Settings(path)
+		return s, true, err
+	})
+}
+
+// Recompress decompresses every file and compresses it again with the
+// specified algorithm and level (see CompressionSettings.Level). If compression doesn't
+// make the file smaller, it is stored uncompressed.
+func (w *Writer) Recompress(c Compression, level int) error {
+	return w.recompress(func(string, *writerBlob) (CompressionSettings, bool, error) {
+		return CompressionSettings{Compression: c, Level: level}, true, nil
+	})
+}
+
+// recompress recompresses each unique blob in the tree. The function returns
+// the settings to use, and whether to recompress it at all.
+func (w *Writer) recompress(fn func(path string, b *writerBlob) (CompressionSettings, bool, error)) error {
+	seen := map[*writerBlob]bool{}
+	return w.walk(func(path string, n *writerNode) error {
+		if n.dir || seen[n.blob] {
+			return nil
+		}
+		seen[n.blob] = true
+
+		s, ok, err := fn(path, n.blob)
+		if err != nil {
+			return fmt.Errorf("recompress %q: %w", path, err)
+		}
+		if !ok {
+			return nil
+		}
+
+		buf, err := n.blob.decompress()
+		if err != nil {
+			return fmt.Errorf("recompress %q: %w", path, err)
+		}
+		if len(buf) == 0 {
+			s.Compression = CompressionNone // rcc never compresses empty files
+		}
+		b, err := compressBlob(buf, s.Compression, s.Level)
+		if err != nil {
+			return fmt.Errorf("recompress %q: %w", path, err)
+		}
+		if len(b.data) >= len(buf) || 100*(len(buf)-len(b.data))/len(buf) < s.Threshold {
+			b.flags, b.data = NodeFlagNone, buf
+		}
+		*n.blob = *b
+		return nil
+	})
+}
+
+// compressBlob compresses data in the format used by Qt.
+func compressBlob(data []byte, c Compression, level int) (*writerBlob, error) {
+	switch c {
+	case CompressionNone:
+		return &writerBlob{flags: NodeFlagNone, data: data}, nil
+	case CompressionZlib:
+		switch {
+		case level == CompressionLevelDefault:
+			level = zlib.DefaultCompression
+		case level < 0:
+			level = zlib.NoCompression
+		}
+		var buf bytes.Buffer
+		buf.Write(appendUint32(nil, uint32(len(data)))) // qCompress header
+		zw, err := zlib.NewWriterLevel(&buf, level)
+		if err != nil {
+			return nil, fmt.Errorf("compress zlib: %w", err)
+		}
+		if _, err := zw.Write(data); err != nil {
+			return nil, fmt.Errorf("compress zlib: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("compress zlib: %w", err)
+		}
+		return &writerBlob{flags: NodeFlagCompressed, data: buf.Bytes()}, nil
+	case CompressionZstd:
+		el := zstd.SpeedDefault
+		if level > 0 {
+			el = zstd.EncoderLevelFromZstd(level)
+		}
+		zw, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(el), zstd.WithEncoderConcurrency(1), zstd.WithZeroFrames(true))
+		if err != nil {
+			return nil, fmt.Errorf("compress zstd: %w", err)
+		}
+		defer zw.Close()
+		return &writerBlob{flags: NodeFlagCompressedZstd, data: zw.EncodeAll(data, nil)}, nil
+	case CompressionBest:
+		zl, sl := level, level
+		if level == CompressionLevelDefault {
+			zl, sl = zlib.BestCompression, 19
+		}
+		zb, err := compressBlob(data, CompressionZlib, zl)
+		if err != nil {
+			return nil, err
+		}
+		sb, err := compressBlob(data, CompressionZstd, sl)
+		if err != nil {
+			return nil, err
+		}
+		if len(sb.data) < len(zb.data) {
+			return sb, nil
+		}
+		return zb, nil
+	}
+	return nil, fmt.Errorf("unknown compression algorithm %s", c)
+}
+
+// decompress returns the original data for the blob.
+func (b *writerBlob) decompress() ([]byte, error) {
+	switch {
+	case b.flags.Has(NodeFlagCompressed):
+		if len(b.data) < 4 {
+			return nil, fmt.Errorf("read qCompress original size header from zlib data: too short")
+		}
+		zr, err := newZlibReader(bytes.NewReader(b.data[4:]))
+		if err != nil {
+			return nil, fmt.Errorf("open zlib reader: %w", err)
+		}
+		defer zr.Close()
+		buf, err := ioutil.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("decompress zlib: %w", err)
+		}
+		if sz := binary.BigEndian.Uint32(b.data); int(sz) != len(buf) {
+			return nil, fmt.Errorf("decompress zlib: qCompress header size %d doesn't match actual size %d", sz, len(buf))
+		}
+		return buf, nil
+	case b.flags.Has(NodeFlagCompressedZstd):
+		zr, err := getZstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("open zstd reader: %w", err)
+		}
+		defer putZstdDecoder(zr)
+		buf, err := zr.DecodeAll(b.data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompress zstd: %w", err)
+		}
+		return buf, nil
+	}
+	return b.data, nil
+}
@@ -0,0 +1,111 @@
+package qrc
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCompressBlob(t *testing.T) {
+	data := bytes.Repeat([]byte("hello world "), 100)
+	for _, c := range []Compression{CompressionNone, CompressionZlib, CompressionZstd} {
+		for _, d := range [][]byte{nil, data} {
+			b, err := compressBlob(d, c, CompressionLevelDefault)
+			if err != nil {
+				t.Fatalf("%s: compress: %v", c, err)
+			}
+			if b.flags != c.Flag() {
+				t.Errorf("%s: incorrect flags %s", c, b.flags)
+			}
+			if c != CompressionNone && len(d) != 0 && len(b.data) >= len(d) {
+				t.Errorf("%s: data not compressed", c)
+			}
+			x, err := b.decompress()
+			if err != nil {
+				t.Fatalf("%s: decompress: %v", c, err)
+			}
+			if !bytes.Equal(x, d) {
+				t.Errorf("%s: incorrect decompressed data", c)
+			}
+		}
+	}
+
+	if b, err := compressBlob(data, CompressionZlib, CompressionLevelStore); err != nil {
+		t.Fatalf("zlib store: compress: %v", err)
+	} else if len(b.data) <= len(data) {
+		t.Errorf("zlib store: expected data to be stored without compression")
+	}
+
+	bb, err := compressBlob(data, CompressionBest, CompressionLevelDefault)
+	if err != nil {
+		t.Fatalf("best: compress: %v", err)
+	}
+	if b, err := compressBlob(data, CompressionBest, CompressionLevelStore); err != nil {
+		t.Fatalf("best store: compress: %v", err)
+	} else if b.flags != NodeFlagCompressedZstd || len(b.data) < len(bb.data) {
+		t.Errorf("best store: expected zstd at the default level, got %s with %d bytes (highest level: %d bytes)", b.flags, len(b.data), len(bb.data))
+	}
+}
+
+func TestCompressionPolicy(t *testing.T) {
+	compressible := bytes.Repeat([]byte("a"), 1000)
+	// ~40% compressible
+	half := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(half[:600])
+
+	w := NewWriter()
+	w.Add("a.txt", CountryAnyCountry, LanguageC, time.Time{}, compressible)
+	w.Add("half.bin", CountryAnyCountry, LanguageC, time.Time{}, half)
+	w.Add("empty.txt", CountryAnyCountry, LanguageC, time.Time{}, nil)
+	w.Add("img/a.png", CountryAnyCountry, LanguageC, time.Time{}, compressible)
+	w.Add("img/b.svg", CountryAnyCountry, LanguageC, time.Time{}, compressible)
+
+	p := DefaultCompressionPolicy()
+	p.Overrides = []CompressionOverride{
+		{"img/*.png", CompressionSettings{Compression: CompressionNone}},
+		{"img/*", CompressionSettings{Compression: CompressionZstd, Level: 19}},
+	}
+	if err := w.Compress(p); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+
+	exp := map[string]NodeFlag{
+		"a.txt":     NodeFlagCompressed,
+		"half.bin":  NodeFlagNone, // below threshold
+		"empty.txt": NodeFlagNone,
+		"img/a.png": NodeFlagNone,
+		"img/b.svg": NodeFlagCompressedZstd,
+	}
+	if err := testReader(t, w, 3).Walk(func(path string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && entry.Flags() != exp[path] {
+			t.Errorf("%q: expected flags %s, got %s", path, exp[path], entry.Flags())
+		}
+		return nil
+	}, false); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+
+	p.Threshold = 0
+	if err := w.Compress(p); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := testReader(t, w, 3).Walk(func(path string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == "half.bin" && entry.Flags() != NodeFlagCompressed {
+			t.Errorf("%q: expected file to be compressed with no threshold", path)
+		}
+		return nil
+	}, false); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+
+	if _, err := (CompressionPolicy{Overrides: []CompressionOverride{{Pattern: "["}}}).Settings("a"); err == nil {
+		t.Errorf("expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,85 @@
+package qrc
+
+import (
+	"fmt"
+	"io"
+)
+
+// ConvertOptions controls how a resource is converted by Convert.
+type ConvertOptions struct {
+	// FormatVersion is the format version to write.
+	FormatVersion int
+
+	// Compression, if set, is used to recompress every file (see
+	// Writer.Compress).
+	Compression *CompressionPolicy
+
+	// AllowLoss allows information to be lost when converting to an older
+	// format version. If Warn is set, it is called for each file which lost
+	// information.
+	AllowLoss bool
+	Warn      func(path string, msg string)
+}
+
+// Convert rewrites a Reader to a new format version, optionally recompressing
+// it. Since zstd compression requires format version 3, zstd-compressed files
+// are recompressed with zlib when writing older versions.
+func Convert(out io.Writer, r *Reader, opt ConvertOptions) error {
+	if opt.FormatVersion < 1 || opt.FormatVersion > 3 {
+		return fmt.Errorf("convert: unsupported format version %d", opt.FormatVersion)
+	}
+
+	if opt.Compression != nil && opt.FormatVersion < 3 {
+		zstd := func(c Compression) bool {
+			return c == CompressionZstd || c == CompressionBest
+		}
+		if zstd(opt.Compression.Compression) {
+			return fmt.Errorf("convert: zstd compression requires format version 3")
+		}
+		for _, o := range opt.Compression.Overrides {
+			if zstd(o.Compression) {
+				return fmt.Errorf("convert: zstd compression (for %q) requires format version 3", o.Pattern)
+			}
+		}
+	}
+
+	w := NewWriter()
+	if err := w.AddReader("", r, ConflictError); err != nil {
+		return fmt.Errorf("convert: read resources: %w", err)
+	}
+
+	if opt.Compression != nil {
+		if err := w.Compress(*opt.Compression); err != nil {
+			return fmt.Errorf("convert: %w", err)
+		}
+	}
+
+	if opt.FormatVersion < 3 {
+		if err := w.recompress(func(_ string, b *writerBlob) (CompressionSettings, bool, error) {
+			return CompressionSettings{Compression: CompressionZlib}, b.flags.Has(NodeFlagCompressedZstd), nil
+		}); err != nil {
+			return fmt.Errorf("convert: %w", err)
+		}
+	}
+
+	var loss []string
+	if err := w.walk(func(path string, n *writerNode) error {
+		if opt.FormatVersion < 2 && !n.modTime.IsZero() {
+			if opt.Warn != nil && opt.AllowLoss {
+				opt.Warn(path, "modification time will be lost")
+			}
+			loss = append(loss, path)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("convert: %w", err)
+	}
+	if len(loss) != 0 && !opt.AllowLoss {
+		return fmt.Errorf("convert: format version %d would lose the modification time of %d files (e.g. %q)", opt.FormatVersion, len(loss), loss[0])
+	}
+
+	if err := w.WriteRCC(out, opt.FormatVersion); err != nil {
+		return fmt.Errorf("convert: %w", err)
+	}
+	return nil
+}
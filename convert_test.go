@@ -0,0 +1,79 @@
+package qrc
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestConvert(t *testing.T) {
+	w := NewWriter()
+	w.Add("a.txt", CountryAnyCountry, LanguageC, time.Unix(1600000000, 0), bytes.Repeat([]byte("a"), 1000))
+	w.Add("b.txt", CountryAnyCountry, LanguageC, time.Time{}, []byte("b"))
+	if err := w.Recompress(CompressionZstd, CompressionLevelDefault); err != nil {
+		t.Fatalf("recompress: %v", err)
+	}
+	r := testReader(t, w, 3)
+
+	exp := map[string]string{
+		"a.txt": string(bytes.Repeat([]byte("a"), 1000)),
+		"b.txt": "b",
+	}
+
+	for _, c := range []struct {
+		opt   ConvertOptions
+		err   bool
+		flags NodeFlag
+	}{
+		{ConvertOptions{FormatVersion: 3}, false, NodeFlagCompressedZstd},
+		{ConvertOptions{FormatVersion: 2}, false, NodeFlagCompressed},
+		{ConvertOptions{FormatVersion: 1}, true, 0},
+		{ConvertOptions{FormatVersion: 1, AllowLoss: true}, false, NodeFlagCompressed},
+		{ConvertOptions{FormatVersion: 2, Compression: &CompressionPolicy{CompressionSettings: CompressionSettings{Compression: CompressionZstd}}}, true, 0},
+		{ConvertOptions{FormatVersion: 2, Compression: &CompressionPolicy{Overrides: []CompressionOverride{{"*", CompressionSettings{Compression: CompressionBest}}}}}, true, 0},
+		{ConvertOptions{FormatVersion: 3, Compression: &CompressionPolicy{}}, false, NodeFlagNone},
+		{ConvertOptions{FormatVersion: 3, Compression: &CompressionPolicy{CompressionSettings: CompressionSettings{Compression: CompressionZlib}}}, false, NodeFlagCompressed},
+	} {
+		var warned int
+		c.opt.Warn = func(string, string) { warned++ }
+
+		var buf bytes.Buffer
+		if err := Convert(&buf, r, c.opt); err != nil {
+			if !c.err {
+				t.Errorf("%+v: unexpected error: %v", c.opt, err)
+			}
+			continue
+		} else if c.err {
+			t.Errorf("%+v: expected error", c.opt)
+			continue
+		}
+
+		if c.opt.AllowLoss && warned != 1 {
+			t.Errorf("%+v: expected 1 warning, got %d", c.opt, warned)
+		}
+
+		cr, err := NewReaderFromRCC(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("%+v: read converted: %v", c.opt, err)
+		}
+		if cr.format != c.opt.FormatVersion {
+			t.Errorf("%+v: incorrect format version %d", c.opt, cr.format)
+		}
+		testFilesEqual(t, cr, exp)
+
+		if err := cr.Walk(func(path string, entry *ReaderEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if path == "a.txt" && entry.Flags() != c.flags {
+				t.Errorf("%+v: %q: expected flags %s, got %s", c.opt, path, c.flags, entry.Flags())
+			}
+			if path == "b.txt" && entry.Flags() != NodeFlagNone {
+				t.Errorf("%+v: %q: expected incompressible file to be stored", c.opt, path)
+			}
+			return nil
+		}, false); err != nil {
+			t.Fatalf("walk: %v", err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package qrc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CPPOptions controls the output of Writer.WriteCPP.
+type CPPOptions struct {
+	// Name is the resource name (rcc's -name), which is used as a suffix for
+	// the qInitResources and qCleanupResources functions.
+	Name string
+
+	// NoNamespace disables support for Qt builds with QT_NAMESPACE (rcc's
+	// -no-namespace).
+	NoNamespace bool
+}
+
+// WriteCPP writes the tree as C++ source code like rcc's C++ generator (i.e.
+// qrc_<name>.cpp). The tree is registered using qRegisterResourceData with the
+// specified format version during static initialization, and the
+// qInitResources_<name> and qCleanupResources_<name> functions are defined.
+func (w *Writer) WriteCPP(out io.Writer, formatVersion int, opt CPPOptions) error {
+	l, err := w.layout(formatVersion)
+	if err != nil {
+		return err
+	}
+
+	c := &codeWriter{w: bufio.NewWriter(out)}
+
+	c.str("/****************************************************************************\n")
+	c.str("** Resource object code\n")
+	c.str("**\n")
+	c.str("** Created by: github.com/pgaskin/qrc\n")
+	c.str("**\n")
+	c.str("** WARNING! All changes made in this file will be lost!\n")
+	c.str("*****************************************************************************/\n\n")
+
+	// an empty array isn't valid C++, so pass a null pointer instead (this
+	// also applies to the names for an empty tree)
+	data := "nullptr"
+	if len(l.data) != 0 {
+		data = "qt_resource_data"
+		c.str("static const unsigned char qt_resource_data[] = {\n")
+		c.data(l)
+		c.str("\n};\n\n")
+	}
+
+	names := "nullptr"
+	if len(l.names) != 0 {
+		names = "qt_resource_name"
+		c.str("static const unsigned char qt_resource_name[] = {\n")
+		c.names(l)
+		c.str("\n};\n\n")
+	}
+
+	c.str("static const unsigned char qt_resource_struct[] = {\n")
+	c.tree(l)
+	c.str("\n};\n\n")
+
+	var initName string
+	if opt.Name != "" {
+		initName = "_" + cppIdentifier(opt.Name)
+	}
+	initResources, cleanupResources := "qInitResources"+initName, "qCleanupResources"+initName
+
+	ns := func(name string) string {
+		if opt.NoNamespace {
+			return name
+		}
+		return "QT_RCC_PREPEND_NAMESPACE(" + name + ")"
+	}
+	mangle := func(name string) string {
+		if opt.NoNamespace {
+			return name
+		}
+		return "QT_RCC_MANGLE_NAMESPACE(" + name + ")"
+	}
+
+	if !opt.NoNamespace {
+		c.str("#ifdef QT_NAMESPACE\n")
+		c.str("#  define QT_RCC_PREPEND_NAMESPACE(name) ::QT_NAMESPACE::name\n")
+		c.str("#  define QT_RCC_MANGLE_NAMESPACE0(x) x\n")
+		c.str("#  define QT_RCC_MANGLE_NAMESPACE1(a, b) a##_##b\n")
+		c.str("#  define QT_RCC_MANGLE_NAMESPACE2(a, b) QT_RCC_MANGLE_NAMESPACE1(a,b)\n")
+		c.str("#  define QT_RCC_MANGLE_NAMESPACE(name) QT_RCC_MANGLE_NAMESPACE2( \\\n")
+		c.str("        QT_RCC_MANGLE_NAMESPACE0(name), QT_RCC_MANGLE_NAMESPACE0(QT_NAMESPACE))\n")
+		c.str("#else\n")
+		c.str("#   define QT_RCC_PREPEND_NAMESPACE(name) name\n")
+		c.str("#   define QT_RCC_MANGLE_NAMESPACE(name) name\n")
+		c.str("#endif\n\n")
+		c.str("#ifdef QT_NAMESPACE\n")
+		c.str("namespace QT_NAMESPACE {\n")
+		c.str("#endif\n\n")
+	}
+
+	c.str("bool qRegisterResourceData(int, const unsigned char *, const unsigned char *, const unsigned char *);\n")
+	c.str("bool qUnregisterResourceData(int, const unsigned char *, const unsigned char *, const unsigned char *);\n\n")
+
+	// reference the features from QtCore so it fails to link if they are
+	// missing rather than failing at runtime
+	if l.flags&(NodeFlagCompressed|NodeFlagCompressedZstd) != 0 {
+		c.str("#if defined(__ELF__) || defined(__APPLE__)\n")
+		if l.flags.Has(NodeFlagCompressedZstd) {
+			c.str("static inline unsigned char qResourceFeatureZstd()\n")
+			c.str("{\n")
+			c.str("    extern const unsigned char qt_resourceFeatureZstd;\n")
+			c.str("    return qt_resourceFeatureZstd;\n")
+			c.str("}\n")
+		}
+		if l.flags.Has(NodeFlagCompressed) {
+			c.str("static inline unsigned char qResourceFeatureZlib()\n")
+			c.str("{\n")
+			c.str("    extern const unsigned char qt_resourceFeatureZlib;\n")
+			c.str("    return qt_resourceFeatureZlib;\n")
+			c.str("}\n")
+		}
+		c.str("#else\n")
+		if l.flags.Has(NodeFlagCompressedZstd) {
+			c.str("unsigned char qResourceFeatureZstd();\n")
+		}
+		if l.flags.Has(NodeFlagCompressed) {
+			c.str("unsigned char qResourceFeatureZlib();\n")
+		}
+		c.str("#endif\n\n")
+	}
+
+	if !opt.NoNamespace {
+		c.str("#ifdef QT_NAMESPACE\n}\n#endif\n\n")
+	}
+
+	c.str("int " + mangle(initResources) + "();\n")
+	c.str("int " + mangle(initResources) + "()\n{\n")
+	c.str("    int version = " + strconv.Itoa(formatVersion) + ";\n")
+	c.str("    " + ns("qRegisterResourceData") + "\n")
+	c.str("        (version, qt_resource_struct, " + names + ", " + data + ");\n")
+	c.str("    return 1;\n")
+	c.str("}\n\n")
+
+	c.str("int " + mangle(cleanupResources) + "();\n")
+	c.str("int " + mangle(cleanupResources) + "()\n{\n")
+	c.str("    int version = " + strconv.Itoa(formatVersion) + ";\n")
+	if l.flags.Has(NodeFlagCompressedZstd) {
+		c.str("    version += " + ns("qResourceFeatureZstd()") + ";\n")
+	}
+	if l.flags.Has(NodeFlagCompressed) {
+		c.str("    version += " + ns("qResourceFeatureZlib()") + ";\n")
+	}
+	c.str("    " + ns("qUnregisterResourceData") + "\n")
+	c.str("       (version, qt_resource_struct, " + names + ", " + data + ");\n")
+	c.str("    return 1;\n")
+	c.str("}\n\n")
+
+	c.str("namespace {\n")
+	c.str("   struct initializer {\n")
+	c.str("       initializer() { " + mangle(initResources) + "(); }\n")
+	c.str("       ~initializer() { " + mangle(cleanupResources) + "(); }\n")
+	c.str("   } dummy;\n")
+	c.str("}\n")
+
+	if err := c.w.Flush(); err != nil {
+		return fmt.Errorf("write cpp: %w", err)
+	}
+	return nil
+}
+
+// cppIdentifier replaces characters which aren't valid in a C++ identifier
+// with underscores.
+func cppIdentifier(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,83 @@
+package qrc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// testWriter creates a Writer with a small tree for testing code generators.
+func testWriter(t testing.TB) *Writer {
+	t.Helper()
+	mt := time.Unix(1600000000, 0)
+	w := NewWriter()
+	for _, f := range []struct {
+		p string
+		c Country
+		l Language
+		d string
+	}{
+		{"main.qml", CountryAnyCountry, LanguageC, "import QtQuick 2.0\n\nItem {}\n"},
+		{"i18n/hello.txt", CountryAnyCountry, LanguageC, "hello"},
+		{"i18n/hello.txt", CountryCanada, LanguageFrench, "bonjour"},
+		{"img/icon.svg", CountryAnyCountry, LanguageC, string(bytes.Repeat([]byte("<svg/>"), 20))},
+	} {
+		if err := w.Add(f.p, f.c, f.l, mt, []byte(f.d)); err != nil {
+			t.Fatalf("add %q: %v", f.p, err)
+		}
+	}
+	if err := w.Compress(CompressionPolicy{Overrides: []CompressionOverride{
+		{"img/*", CompressionSettings{Compression: CompressionZlib, Level: 9}},
+	}}); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	return w
+}
+
+func TestWriteCPP(t *testing.T) {
+	zstd := func(t testing.TB) *Writer {
+		w := testWriter(t)
+		if err := w.Add("zstd.txt", CountryAnyCountry, LanguageC, time.Time{}, bytes.Repeat([]byte("zstd"), 20)); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if err := w.Compress(CompressionPolicy{Overrides: []CompressionOverride{
+			{"img/*", CompressionSettings{Compression: CompressionZlib, Level: 9}},
+			{"zstd.txt", CompressionSettings{Compression: CompressionZstd}},
+		}}); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		return w
+	}
+	empty := func(t testing.TB) *Writer {
+		w := NewWriter()
+		if err := w.Mkdir("a", time.Time{}); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		return w
+	}
+	for _, c := range []struct {
+		fixture string
+		w       func(testing.TB) *Writer
+		format  int
+		opt     CPPOptions
+	}{
+		{"testdata/qrc_test.cpp", testWriter, 2, CPPOptions{Name: "test"}},
+		{"testdata/qrc_test_nons.cpp", testWriter, 1, CPPOptions{Name: "test-1", NoNamespace: true}},
+		{"testdata/qrc_test_zstd.cpp", zstd, 3, CPPOptions{Name: "test"}},
+		{"testdata/qrc_test_empty.cpp", empty, 2, CPPOptions{Name: "test"}},
+		{"testdata/qrc_test_empty_tree.cpp", func(testing.TB) *Writer { return NewWriter() }, 2, CPPOptions{Name: "test"}},
+	} {
+		var buf bytes.Buffer
+		if err := c.w(t).WriteCPP(&buf, c.format, c.opt); err != nil {
+			t.Fatalf("%s: write cpp: %v", c.fixture, err)
+		}
+		exp, err := ioutil.ReadFile(c.fixture)
+		if err != nil {
+			t.Fatalf("%s: read fixture: %v", c.fixture, err)
+		}
+		if !bytes.Equal(buf.Bytes(), exp) {
+			t.Errorf("%s: output doesn't match fixture:\n%s", c.fixture, buf.String())
+		}
+	}
+}
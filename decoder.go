@@ -0,0 +1,132 @@
+package qrc
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var errReadAfterClose = errors.New("read after close")
+
+// zstdDecoders is a bounded pool of zstd decoders. A decoder used for streaming
+// has a goroutine which is only stopped by closing it, so sync.Pool can't be
+// used.
+var zstdDecoders = make(chan *zstd.Decoder, runtime.NumCPU())
+
+// zstdDecodersOpen is the number of decoders which haven't been closed yet.
+var zstdDecodersOpen int64
+
+// zlibReaders is a pool of zlib readers, which can be reset with zlib.Resetter.
+var zlibReaders sync.Pool
+
+// getZstdDecoder gets a decoder from the pool, or creates a new one.
+func getZstdDecoder() (*zstd.Decoder, error) {
+	select {
+	case d := <-zstdDecoders:
+		return d, nil
+	default:
+		d, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err == nil {
+			atomic.AddInt64(&zstdDecodersOpen, 1)
+		}
+		return d, err
+	}
+}
+
+// putZstdDecoder returns a decoder to the pool, or closes it if the pool is
+// full.
+func putZstdDecoder(d *zstd.Decoder) {
+	// stop decoding the previous stream and release the reader (an empty
+	// bytes.Buffer is decoded synchronously)
+	if err := d.Reset(new(bytes.Buffer)); err != nil {
+		closeZstdDecoder(d)
+		return
+	}
+	select {
+	case zstdDecoders <- d:
+	default:
+		closeZstdDecoder(d)
+	}
+}
+
+func closeZstdDecoder(d *zstd.Decoder) {
+	d.Close()
+	atomic.AddInt64(&zstdDecodersOpen, -1)
+}
+
+// newZstdReader returns a reader which decompresses a zstd stream from r using a
+// pooled decoder. The decoder is returned to the pool when closed.
+func newZstdReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := getZstdDecoder()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Reset(r); err != nil {
+		putZstdDecoder(d)
+		return nil, err
+	}
+	return &zstdReadCloser{d}, nil
+}
+
+type zstdReadCloser struct {
+	d *zstd.Decoder
+}
+
+func (z *zstdReadCloser) Read(p []byte) (int, error) {
+	if z.d == nil {
+		return 0, errReadAfterClose
+	}
+	return z.d.Read(p)
+}
+
+func (z *zstdReadCloser) Close() error {
+	if z.d != nil {
+		putZstdDecoder(z.d)
+		z.d = nil
+	}
+	return nil
+}
+
+// newZlibReader returns a reader which decompresses a zlib stream from r using
+// a pooled reader. The reader is returned to the pool when closed.
+func newZlibReader(r io.Reader) (io.ReadCloser, error) {
+	if zr, ok := zlibReaders.Get().(io.ReadCloser); ok {
+		if err := zr.(zlib.Resetter).Reset(r, nil); err != nil {
+			zlibReaders.Put(zr)
+			return nil, err
+		}
+		return &zlibReadCloser{zr}, nil
+	}
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &zlibReadCloser{zr}, nil
+}
+
+type zlibReadCloser struct {
+	zr io.ReadCloser
+}
+
+func (z *zlibReadCloser) Read(p []byte) (int, error) {
+	if z.zr == nil {
+		return 0, errReadAfterClose
+	}
+	return z.zr.Read(p)
+}
+
+func (z *zlibReadCloser) Close() error {
+	if z.zr == nil {
+		return nil
+	}
+	err := z.zr.Close()
+	zlibReaders.Put(z.zr)
+	z.zr = nil
+	return err
+}
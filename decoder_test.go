@@ -0,0 +1,105 @@
+package qrc
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDecoderPool(t *testing.T) {
+	w := NewWriter()
+	for i := 0; i < 64; i++ {
+		if err := w.Add(fmt.Sprintf("%d.txt", i), CountryAnyCountry, LanguageC, time.Time{}, bytes.Repeat([]byte(fmt.Sprint(i)), 1000)); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if err := w.Compress(CompressionPolicy{
+		CompressionSettings: CompressionSettings{Compression: CompressionZstd},
+		Overrides: []CompressionOverride{
+			{"*[02468].txt", CompressionSettings{Compression: CompressionZlib}},
+		},
+	}); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	r := testReader(t, w, 3)
+
+	c, err := r.Children()
+	if err != nil {
+		t.Fatalf("read children: %v", err)
+	}
+
+	check := func(e *ReaderEntry) error {
+		rc, err := e.Open()
+		if err != nil {
+			return fmt.Errorf("%s: open: %w", e.Name(), err)
+		}
+		buf, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return fmt.Errorf("%s: read: %w", e.Name(), err)
+		}
+		if exp := bytes.Repeat([]byte(e.Name()[:len(e.Name())-4]), 1000); !bytes.Equal(buf, exp) {
+			return fmt.Errorf("%s: incorrect contents", e.Name())
+		}
+		if err := rc.Close(); err != nil {
+			return fmt.Errorf("%s: close: %w", e.Name(), err)
+		}
+		if err := rc.Close(); err != nil {
+			return fmt.Errorf("%s: second close: %w", e.Name(), err)
+		}
+		if _, err := rc.Read(make([]byte, 1)); err == nil {
+			return fmt.Errorf("%s: expected error for read after close", e.Name())
+		}
+		return nil
+	}
+
+	// warm up the pool
+	for _, e := range c {
+		if err := check(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before := atomic.LoadInt64(&zstdDecodersOpen) - int64(len(zstdDecoders)) // in use elsewhere
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				for _, e := range c {
+					if err := check(e); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// partially read files must also release decoders when closed
+	for _, e := range c {
+		rc, err := e.Open()
+		if err != nil {
+			t.Fatalf("%s: open: %v", e.Name(), err)
+		}
+		rc.Read(make([]byte, 10))
+		rc.Close()
+	}
+
+	if n := len(zstdDecoders); n > cap(zstdDecoders) {
+		t.Errorf("expected at most %d pooled decoders, got %d", cap(zstdDecoders), n)
+	}
+	if after := atomic.LoadInt64(&zstdDecodersOpen) - int64(len(zstdDecoders)); after > before {
+		t.Errorf("expected decoders to be released, but %d are still open outside the pool (before: %d)", after, before)
+	}
+}
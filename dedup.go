@@ -0,0 +1,51 @@
+package qrc
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Deduplicate makes files with identical (uncompressed) contents share the
+// same data in the resource, which is supported by Qt since tree nodes only
+// store an offset into the data. Of the duplicates, the smallest stored
+// representation is kept. It returns the number of bytes saved in the data
+// section. Any recompression (e.g. Writer.Recompress) done afterwards is only
+// done once for each unique blob.
+func (w *Writer) Deduplicate() (int64, error) {
+	var saved int64
+	unique := map[[sha256.Size]byte]*writerBlob{}
+	resolved := map[*writerBlob]*writerBlob{}
+	if err := w.walk(func(path string, n *writerNode) error {
+		if n.dir {
+			return nil
+		}
+		if b, ok := resolved[n.blob]; ok {
+			n.blob = b
+			return nil
+		}
+
+		buf, err := n.blob.decompress()
+		if err != nil {
+			return fmt.Errorf("deduplicate %q: %w", path, err)
+		}
+		h := sha256.Sum256(buf)
+
+		if b, ok := unique[h]; ok {
+			if len(n.blob.data) < len(b.data) {
+				saved += int64(len(b.data)) + 4
+				*b = *n.blob
+			} else {
+				saved += int64(len(n.blob.data)) + 4
+			}
+			resolved[n.blob] = b
+			n.blob = b
+		} else {
+			unique[h] = n.blob
+			resolved[n.blob] = n.blob
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return saved, nil
+}
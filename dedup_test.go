@@ -0,0 +1,75 @@
+package qrc
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDeduplicate(t *testing.T) {
+	icon := bytes.Repeat([]byte("icon"), 100)
+
+	w := NewWriter()
+	w.Add("a/icon.png", CountryAnyCountry, LanguageC, time.Time{}, icon)
+	w.Add("b/icon.png", CountryAnyCountry, LanguageC, time.Time{}, icon)
+	w.Add("b/icon.png", CountryAnyCountry, LanguageFrench, time.Time{}, icon)
+	w.Add("c.txt", CountryAnyCountry, LanguageC, time.Time{}, []byte("c"))
+
+	var before bytes.Buffer
+	if err := w.WriteRCC(&before, 2); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	saved, err := w.Deduplicate()
+	if err != nil {
+		t.Fatalf("deduplicate: %v", err)
+	}
+	if exp := int64(2 * (len(icon) + 4)); saved != exp {
+		t.Errorf("expected %d bytes saved, got %d", exp, saved)
+	}
+
+	var after bytes.Buffer
+	if err := w.WriteRCC(&after, 2); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if int64(before.Len()-after.Len()) != saved {
+		t.Errorf("expected rcc to be %d bytes smaller, got %d", saved, before.Len()-after.Len())
+	}
+
+	if saved, err := w.Deduplicate(); err != nil {
+		t.Fatalf("deduplicate again: %v", err)
+	} else if saved != 0 {
+		t.Errorf("expected nothing to be saved on second deduplication, got %d", saved)
+	}
+
+	if err := w.Recompress(CompressionZlib, CompressionLevelDefault); err != nil {
+		t.Fatalf("recompress: %v", err)
+	}
+
+	r := testReader(t, w, 2)
+	testFilesEqual(t, r, map[string]string{
+		"a/icon.png":                  string(icon),
+		"b/icon.png":                  string(icon),
+		"b/icon[language!French].png": string(icon),
+		"c.txt":                       "c",
+	})
+
+	offsets := map[int64]bool{}
+	if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && path != "c.txt" {
+			offsets[entry.Offset()] = true
+			if entry.Flags() != NodeFlagCompressed {
+				t.Errorf("%q: expected to be compressed", path)
+			}
+		}
+		return nil
+	}, false); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	if len(offsets) != 1 {
+		t.Errorf("expected duplicate files to have the same offset, got %v", offsets)
+	}
+}
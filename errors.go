@@ -0,0 +1,45 @@
+package qrc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors which can be checked for with errors.Is.
+var (
+	ErrBadMagic          = errors.New("invalid magic")
+	ErrUnsupportedFormat = errors.New("unsupported format version")
+	ErrNotDir            = errors.New("not a directory")
+	ErrIsDir             = errors.New("is a directory")
+	ErrInvalidFlags      = errors.New("invalid flags")
+	ErrInvalidName       = errors.New("invalid name")
+	ErrUnknownArchive    = errors.New("unknown archive format")
+)
+
+// Errors returned when a limit set by ReaderOptions is exceeded.
+var (
+	ErrMaxDepth       = errors.New("maximum directory depth exceeded")
+	ErrMaxNodes       = errors.New("maximum node count exceeded")
+	ErrMaxFileSize    = errors.New("maximum decompressed file size exceeded")
+	ErrMaxTotalSize   = errors.New("maximum total decompressed size exceeded")
+	ErrMaxNestedDepth = errors.New("maximum nested rcc depth exceeded")
+	ErrMaxNameLength  = errors.New("maximum name length exceeded")
+)
+
+// Error is an error which occurred while reading a specific part of a Reader.
+// It can be checked for with errors.As, and the underlying error can be checked
+// with errors.Is.
+type Error struct {
+	Region string // header, tree, names, or data
+	Offset int64  // relative to the base io.ReaderAt used when creating the Reader
+	Path   string // the entry being read, separated with forward slashes (empty for the root)
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at %#x (/%s): %v", e.Region, e.Offset, e.Path, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
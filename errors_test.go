@@ -0,0 +1,78 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	names := testAppend(t, nil, func(b []byte) ([]byte, error) { return AppendName(b, "a") })
+	names = testAppend(t, names, func(b []byte) ([]byte, error) { return AppendName(b, "dir") })
+	data := testAppend(t, nil, func(b []byte) ([]byte, error) { return AppendData(b, []byte("garbage")) })
+	rcc := testRCC(t, 2, []Node{
+		{Flags: NodeFlagDirectory, ChildCount: 2, ChildOffset: 1},
+		{NameOffset: 0, Flags: NodeFlagCompressed, DataOffset: 0},
+		{NameOffset: 8, Flags: NodeFlagDirectory, ChildCount: 0, ChildOffset: 0},
+	}, names, data)
+
+	if _, err := NewReaderFromRCC(bytes.NewReader([]byte("qrez\x00\x00\x00\x02"))); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("bad magic: expected ErrBadMagic, got %v", err)
+	}
+	if _, err := NewReaderFromRCC(bytes.NewReader([]byte("qres\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"))); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("bad format: expected ErrUnsupportedFormat, got %v", err)
+	} else if err.Error() != "parse rcc header: header at 0x0 (/): unsupported format version 4" {
+		t.Errorf("bad format: incorrect message %q", err.Error())
+	}
+
+	badRoot := append([]byte(nil), rcc...)
+	badRoot[20+5] = 0xFF // root flags
+	if _, err := NewReaderFromRCC(bytes.NewReader(badRoot)); !errors.Is(err, ErrInvalidFlags) {
+		t.Errorf("bad root: expected ErrInvalidFlags, got %v", err)
+	} else if e := (*Error)(nil); !errors.As(err, &e) || e.Region != "tree" || e.Offset != 20 {
+		t.Errorf("bad root: expected *Error for tree at 20, got %v", err)
+	}
+
+	r, err := NewReaderFromRCC(bytes.NewReader(rcc))
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+	c, err := r.Children()
+	if err != nil {
+		t.Fatalf("read children: %v", err)
+	}
+	file, dir := c[0], c[1]
+
+	for _, tc := range []struct {
+		what   string
+		err    error
+		target error
+		region string
+		offset int64
+		path   string
+	}{
+		{"children of file", func() error { _, err := file.Children(); return err }(), ErrNotDir, "tree", 20 + 22, "a"},
+		{"open dir", func() error { _, err := dir.Open(); return err }(), ErrIsDir, "tree", 20 + 44, "dir"},
+		{"read corrupt file", func() error {
+			rc, err := file.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			_, err = ioutil.ReadAll(rc)
+			return err
+		}(), nil, "data", int64(20 + 3*22 + len(names)), "a"},
+	} {
+		var e *Error
+		if tc.err == nil {
+			t.Errorf("%s: expected error", tc.what)
+		} else if tc.target != nil && !errors.Is(tc.err, tc.target) {
+			t.Errorf("%s: expected %v, got %v", tc.what, tc.target, tc.err)
+		} else if !errors.As(tc.err, &e) {
+			t.Errorf("%s: expected *Error, got %T", tc.what, tc.err)
+		} else if e.Region != tc.region || e.Offset != tc.offset || e.Path != tc.path {
+			t.Errorf("%s: expected %s at %#x (/%s), got %v", tc.what, tc.region, tc.offset, tc.path, e)
+		}
+	}
+}
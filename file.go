@@ -0,0 +1,332 @@
+package qrc
+
+import (
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ReaderFile reads the contents of a file entry. In addition to reading
+// sequentially, it implements io.ReaderAt and io.Seeker. Stored data is read
+// directly from the underlying io.ReaderAt. Compressed data is decompressed
+// from the closest checkpoint before the offset. For zlib data, checkpoints are
+// recorded at deflate block boundaries the first time data is read out of
+// order. For zstd data, checkpoints are at the start of each frame if the
+// frames have the content size. ReadAt is safe for concurrent use, but only one
+// decompressor is used at a time.
+//
+// Errors (including ones returned while reading) are returned as an *Error.
+// The ReaderFile must be closed to release the decompressor, and can't be used
+// afterwards.
+type ReaderFile struct {
+	e      ReaderEntry
+	errFn  func(error) error
+	off    int64             // offset of the (compressed) data in the data region
+	sz     int64             // size of the (compressed) data
+	stored *io.SectionReader // nil if compressed
+	zsize  int64             // size from the qCompress header
+	closed int32             // atomic
+
+	mu   sync.Mutex
+	pos  int64        // offset for Read and Seek
+	dec  *fileReader  // current decompressor, or nil
+	dpos int64        // uncompressed offset of dec
+	end  int64        // uncompressed size, if the end has been reached, or -1
+	cps  []checkpoint // sorted by offset, or nil if not initialized
+	scan *inflateScanner
+	zfcs int64 // total zstd frame content size, or -1
+	hw   int64 // uncompressed offset up to which the output has been counted for MaxTotalSize
+}
+
+var (
+	_ io.ReadCloser = (*ReaderFile)(nil)
+	_ io.ReaderAt   = (*ReaderFile)(nil)
+	_ io.Seeker     = (*ReaderFile)(nil)
+)
+
+// Open opens the contents of the entry for reading. If the entry is a
+// directory, an error wrapping ErrIsDir is returned. Errors (including ones
+// returned while reading) are returned as an *Error. The io.ReadCloser is a
+// *ReaderFile; use OpenFile for random access.
+func (e ReaderEntry) Open() (io.ReadCloser, error) {
+	f, err := e.OpenFile()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// OpenFile is like Open, but returns a *ReaderFile, which also implements
+// io.ReaderAt and io.Seeker. The ReaderFile must be closed to release the
+// decompressor, and can't be used afterwards.
+func (e ReaderEntry) OpenFile() (*ReaderFile, error) {
+	if e.IsDir() {
+		return nil, e.error("tree", e.r.treeOffset+int64(e.i)*nodeSize(e.r.format), ErrIsDir)
+	}
+
+	errFn := func(err error) error {
+		return e.error("data", e.r.dataOffset+int64(e.n.DataOffset), err)
+	}
+
+	if err := e.n.Flags.Valid(); err != nil {
+		return nil, errFn(err)
+	}
+
+	sz, err := e.n.fileSize(e.r.data())
+	if err != nil {
+		return nil, errFn(err)
+	}
+	if err := e.r.data().check(e.n.fileDataOffset(), sz); err != nil {
+		return nil, errFn(err)
+	}
+
+	f := &ReaderFile{
+		e:     e,
+		errFn: errFn,
+		off:   e.n.fileDataOffset(),
+		sz:    sz,
+		end:   -1,
+		zfcs:  -1,
+	}
+	switch {
+	case e.n.Flags.Has(NodeFlagCompressed):
+		var buf [4]byte // note that this isn't strict; qUncompress will accept data longer
+		if _, err := io.ReadFull(io.NewSectionReader(e.r.data(), f.off, f.sz), buf[:]); err != nil {
+			return nil, errFn(fmt.Errorf("read qCompress original size header from zlib data: %w", err))
+		}
+		f.zsize = int64(binary.BigEndian.Uint32(buf[:]))
+		f.off += 4
+		f.sz -= 4
+	case e.n.Flags.Has(NodeFlagCompressedZstd):
+	default:
+		f.stored = io.NewSectionReader(e.r.data(), f.off, f.sz)
+		f.end = f.sz
+		return f, nil
+	}
+	if err := f.restart(checkpoint{}); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UncompressedSize is like ReaderEntry.UncompressedSize, but if the end of the
+// data has already been reached, the actual size is returned.
+func (f *ReaderFile) UncompressedSize() (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.uncompressedSize()
+}
+
+func (f *ReaderFile) uncompressedSize() (int64, error) {
+	if atomic.LoadInt32(&f.closed) != 0 {
+		return 0, f.errFn(errReadAfterClose)
+	}
+	if f.end >= 0 {
+		return f.end, nil
+	}
+	if f.e.n.Flags.Has(NodeFlagCompressed) {
+		return f.zsize, nil
+	}
+	f.checkpoints(0)
+	if f.zfcs >= 0 {
+		return f.zfcs, nil
+	}
+	if cp := f.cps[len(f.cps)-1]; f.dec == nil || f.dpos < cp.out {
+		if err := f.restart(cp); err != nil {
+			return 0, err
+		}
+	}
+	n, err := io.Copy(ioutil.Discard, f.dec)
+	f.dpos += n
+	if err != nil {
+		return 0, err
+	}
+	f.end = f.dpos
+	return f.end, nil
+}
+
+// restart replaces the decompressor with one starting at cp.
+func (f *ReaderFile) restart(cp checkpoint) error {
+	if f.dec != nil {
+		f.dec.Close()
+		f.dec = nil
+	}
+	var (
+		rc  io.ReadCloser
+		err error
+		r   = io.NewSectionReader(f.e.r.data(), f.off+cp.in/8, f.sz-cp.in/8)
+	)
+	switch {
+	case f.e.n.Flags.Has(NodeFlagCompressed):
+		if cp.in == 0 {
+			if rc, err = newZlibReader(r); err != nil {
+				return f.errFn(fmt.Errorf("open zlib reader: %w", err))
+			}
+		} else {
+			ir, err := inflateResume(r, uint(cp.in%8))
+			if err != nil {
+				return f.errFn(fmt.Errorf("open deflate reader at checkpoint: %w", err))
+			}
+			rc = flate.NewReaderDict(ir, cp.dict)
+		}
+	case f.e.n.Flags.Has(NodeFlagCompressedZstd):
+		if rc, err = newZstdReader(r); err != nil {
+			return f.errFn(fmt.Errorf("open zstd reader: %w", err))
+		}
+	default:
+		panic("restart called on stored data")
+	}
+	f.dec = f.e.limit(rc, cp.out, &f.hw, f.errFn)
+	f.dpos = cp.out
+	return nil
+}
+
+// checkpoints records checkpoints up to the uncompressed offset off.
+func (f *ReaderFile) checkpoints(off int64) {
+	if f.e.n.Flags.Has(NodeFlagCompressedZstd) {
+		if f.cps == nil {
+			cps, fcs, err := zstdFrames(io.NewSectionReader(f.e.r.data(), f.off, f.sz), f.sz)
+			if err != nil {
+				cps, fcs = []checkpoint{{}}, -1 // if the data is invalid, the decompressor will return the error
+			}
+			f.cps, f.zfcs = cps, fcs
+		}
+		return
+	}
+	if f.cps == nil {
+		f.cps = []checkpoint{{}}
+		var buf [2]byte
+		if _, err := f.e.r.data().ReadAt(buf[:], f.off); err == nil && buf[1]&0x20 == 0 { // no preset dictionary
+			f.scan = newInflateScanner(io.NewSectionReader(f.e.r.data(), f.off+2, f.sz-2), f.e.r.opt.MaxFileSize)
+		}
+	}
+	for f.scan != nil && f.scan.out <= off {
+		if f.scan.out-f.cps[len(f.cps)-1].out >= checkpointInterval {
+			f.cps = append(f.cps, f.scan.checkpoint())
+			f.cps[len(f.cps)-1].in += 16 // zlib header
+		}
+		if err := f.scan.next(); err != nil {
+			if err == io.EOF {
+				f.end = f.scan.out
+			}
+			f.scan = nil // if the data is invalid, the decompressor will return the error
+		}
+	}
+}
+
+func (f *ReaderFile) Read(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, err := f.readAt(p, f.pos, false)
+	f.pos += int64(n)
+	return n, err
+}
+
+func (f *ReaderFile) ReadAt(p []byte, off int64) (int, error) {
+	if f.stored != nil {
+		return f.readAt(p, off, true)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.readAt(p, off, true)
+}
+
+// readAt reads from the uncompressed offset off. If full is true, it reads
+// until p is full, like io.ReaderAt.
+func (f *ReaderFile) readAt(p []byte, off int64, full bool) (int, error) {
+	if atomic.LoadInt32(&f.closed) != 0 {
+		return 0, f.errFn(errReadAfterClose)
+	}
+	if off < 0 {
+		return 0, f.errFn(errors.New("negative offset"))
+	}
+	if f.stored != nil {
+		n, err := f.stored.ReadAt(p, off)
+		if err != nil && err != io.EOF {
+			err = f.errFn(err)
+		}
+		return n, err
+	}
+	if f.end >= 0 && off >= f.end {
+		return 0, io.EOF
+	}
+	if f.dec == nil || off < f.dpos || off-f.dpos > checkpointInterval {
+		f.checkpoints(off)
+		i := sort.Search(len(f.cps), func(i int) bool {
+			return f.cps[i].out > off
+		}) - 1
+		if cp := f.cps[i]; f.dec == nil || off < f.dpos || cp.out > f.dpos {
+			if err := f.restart(cp); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if off > f.dpos {
+		n, err := io.CopyN(ioutil.Discard, f.dec, off-f.dpos)
+		f.dpos += n
+		if err != nil {
+			if err == io.EOF {
+				f.end = f.dpos
+			}
+			return 0, err
+		}
+	}
+	var (
+		n   int
+		err error
+	)
+	if full {
+		n, err = io.ReadFull(f.dec, p)
+	} else {
+		n, err = f.dec.Read(p)
+	}
+	f.dpos += int64(n)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		f.end, err = f.dpos, io.EOF
+	}
+	return n, err
+}
+
+func (f *ReaderFile) Seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if atomic.LoadInt32(&f.closed) != 0 {
+		return 0, f.errFn(errReadAfterClose)
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		sz, err := f.uncompressedSize()
+		if err != nil {
+			return 0, err
+		}
+		offset += sz
+	default:
+		return 0, f.errFn(fmt.Errorf("invalid whence %d", whence))
+	}
+	if offset < 0 {
+		return 0, f.errFn(errors.New("negative position"))
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (f *ReaderFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	atomic.StoreInt32(&f.closed, 1)
+	if f.dec != nil {
+		err := f.dec.Close()
+		f.dec = nil
+		return err
+	}
+	return nil
+}
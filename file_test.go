@@ -0,0 +1,210 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestReaderFile(t *testing.T) {
+	data := testSeekData(4 << 20)
+
+	enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedFastest))
+	var zframes []byte
+	for i := 0; i < len(data); i += 1 << 19 {
+		zframes = enc.EncodeAll(data[i:minInt(i+1<<19, len(data))], zframes)
+	}
+
+	w := NewWriter()
+	for _, p := range []string{"stored", "zlib", "zstd"} {
+		if err := w.Add(p, CountryAnyCountry, LanguageC, time.Time{}, data); err != nil {
+			t.Fatalf("add %q: %v", p, err)
+		}
+	}
+	if err := w.Compress(CompressionPolicy{Overrides: []CompressionOverride{
+		{"zlib", CompressionSettings{Compression: CompressionZlib}},
+		{"zstd", CompressionSettings{Compression: CompressionZstd}},
+	}}); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := w.AddRaw("zstd-frames", CountryAnyCountry, LanguageC, time.Time{}, NodeFlagCompressedZstd, zframes); err != nil {
+		t.Fatalf("add raw: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := w.WriteRCC(&buf, 3); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+
+	for _, c := range []struct {
+		path  string
+		flags NodeFlag
+		cps   bool
+	}{
+		{"stored", NodeFlagNone, false},
+		{"zlib", NodeFlagCompressed, true},
+		{"zstd", NodeFlagCompressedZstd, false},
+		{"zstd-frames", NodeFlagCompressedZstd, true},
+	} {
+		t.Run(c.path, func(t *testing.T) {
+			r, err := NewReaderFromRCC(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("read rcc: %v", err)
+			}
+			e, err := r.Stat(c.path, CountryAnyCountry, LanguageC)
+			if err != nil {
+				t.Fatalf("stat: %v", err)
+			}
+			if e.Flags() != c.flags {
+				t.Fatalf("expected flags %s, got %s", c.flags, e.Flags())
+			}
+			if sz, err := e.UncompressedSize(); err != nil {
+				t.Errorf("uncompressed size: unexpected error: %v", err)
+			} else if sz != int64(len(data)) {
+				t.Errorf("uncompressed size: expected %d, got %d", len(data), sz)
+			}
+
+			f, err := e.OpenFile()
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 20; i++ {
+				off := rng.Int63n(int64(len(data)))
+				b := make([]byte, rng.Intn(1<<16))
+				n, err := f.ReadAt(b, off)
+				if exp := minInt(len(b), len(data)-int(off)); n != exp {
+					t.Errorf("read at %d: expected %d bytes, got %d (err: %v)", off, exp, n, err)
+				} else if n != len(b) && err != io.EOF {
+					t.Errorf("read at %d: expected io.EOF for short read, got %v", off, err)
+				} else if n == len(b) && err != nil {
+					t.Errorf("read at %d: unexpected error: %v", off, err)
+				} else if !bytes.Equal(b[:n], data[off:off+int64(n)]) {
+					t.Errorf("read at %d: incorrect data", off)
+				}
+			}
+			if c.cps && len(f.cps) < 2 {
+				t.Errorf("expected checkpoints to be recorded, got %d", len(f.cps))
+			}
+
+			if off, err := f.Seek(-100, io.SeekEnd); err != nil {
+				t.Errorf("seek end: unexpected error: %v", err)
+			} else if off != int64(len(data))-100 {
+				t.Errorf("seek end: expected offset %d, got %d", len(data)-100, off)
+			} else if b, err := ioutil.ReadAll(f); err != nil {
+				t.Errorf("seek end: read: unexpected error: %v", err)
+			} else if !bytes.Equal(b, data[len(data)-100:]) {
+				t.Errorf("seek end: incorrect data")
+			}
+
+			if _, err := f.Seek(1<<20, io.SeekStart); err != nil {
+				t.Errorf("seek start: unexpected error: %v", err)
+			} else if _, err := f.Seek(-(1 << 19), io.SeekCurrent); err != nil {
+				t.Errorf("seek current: unexpected error: %v", err)
+			} else if b, err := ioutil.ReadAll(f); err != nil {
+				t.Errorf("seek current: read: unexpected error: %v", err)
+			} else if !bytes.Equal(b, data[1<<19:]) {
+				t.Errorf("seek current: incorrect data")
+			}
+
+			if _, err := f.Seek(-1, io.SeekStart); err == nil {
+				t.Errorf("seek negative: expected error")
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func(i int64) {
+					defer wg.Done()
+					b := make([]byte, 1000)
+					for j := int64(0); j < 8; j++ {
+						off := (i*8 + j) * int64(len(data)/32)
+						if _, err := f.ReadAt(b, off); err != nil {
+							t.Errorf("concurrent read at %d: unexpected error: %v", off, err)
+						} else if !bytes.Equal(b, data[off:off+1000]) {
+							t.Errorf("concurrent read at %d: incorrect data", off)
+						}
+					}
+				}(int64(i))
+			}
+			wg.Wait()
+
+			if err := f.Close(); err != nil {
+				t.Errorf("close: unexpected error: %v", err)
+			}
+			if _, err := f.ReadAt(make([]byte, 1), 0); !errors.Is(err, errReadAfterClose) {
+				t.Errorf("read after close: expected error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		r, err := NewReaderFromRCCWithOptions(bytes.NewReader(buf.Bytes()), &ReaderOptions{MaxFileSize: 1 << 20})
+		if err != nil {
+			t.Fatalf("read rcc: %v", err)
+		}
+		for _, p := range []string{"zlib", "zstd", "zstd-frames"} {
+			rc, err := r.OpenFile(p, CountryAnyCountry, LanguageC)
+			if err != nil {
+				t.Fatalf("open %q: %v", p, err)
+			}
+			if _, err := rc.ReadAt(make([]byte, 1), 2<<20); !errors.Is(err, ErrMaxFileSize) {
+				t.Errorf("read %q past limit: expected ErrMaxFileSize, got %v", p, err)
+			}
+			if _, err := rc.ReadAt(make([]byte, 1), 1000); err != nil {
+				t.Errorf("read %q before limit: unexpected error: %v", p, err)
+			}
+			rc.Close()
+		}
+	})
+
+	t.Run("TotalLimit", func(t *testing.T) {
+		r, err := NewReaderFromRCCWithOptions(bytes.NewReader(buf.Bytes()), &ReaderOptions{MaxTotalSize: int64(len(data)) * 3 / 2})
+		if err != nil {
+			t.Fatalf("read rcc: %v", err)
+		}
+		for _, p := range []string{"zlib", "zstd", "zstd-frames"} {
+			f, err := r.OpenFile(p, CountryAnyCountry, LanguageC)
+			if err != nil {
+				t.Fatalf("open %q: %v", p, err)
+			}
+			// reading the same data repeatedly shouldn't count it again
+			b := make([]byte, 1<<16)
+			for i := 0; i < 4; i++ {
+				for _, off := range []int64{0, int64(len(data)) / 2, int64(len(data)) - int64(len(b))} {
+					if _, err := f.ReadAt(b, off); err != nil {
+						t.Fatalf("read %q at %d: unexpected error: %v", p, off, err)
+					}
+				}
+			}
+			if _, err := ioutil.ReadAll(io.NewSectionReader(f, 0, int64(len(data)))); err != nil {
+				t.Fatalf("read %q: unexpected error: %v", p, err)
+			}
+			f.Close()
+			if total := r.limits.total; total > int64(len(data)) {
+				t.Errorf("read %q: expected at most %d bytes to be counted, got %d", p, len(data), total)
+			}
+
+			// but reading it from another file should
+			r.limits.total = 0
+			for i, exp := range []error{nil, ErrMaxTotalSize} {
+				rc, err := r.Open(p, CountryAnyCountry, LanguageC)
+				if err != nil {
+					t.Fatalf("open %q: %v", p, err)
+				}
+				if _, err := ioutil.ReadAll(rc); !errors.Is(err, exp) {
+					t.Errorf("read %q (%d): expected %v, got %v", p, i, exp, err)
+				}
+				rc.Close()
+			}
+			r.limits.total = 0
+		}
+	})
+}
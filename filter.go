@@ -0,0 +1,95 @@
+package qrc
+
+// LocaleFilter selects which locale variants of files to keep.
+type LocaleFilter struct {
+	// Languages is the list of languages to keep, in order of preference.
+	// Files for LanguageAnyLanguage and LanguageC are always kept.
+	Languages []Language
+
+	// Countries is the list of countries to keep, in order of preference. If
+	// empty, files for all countries are kept. Files for CountryAnyCountry are
+	// always kept.
+	Countries []Country
+
+	// Collapse replaces all variants of a file with the most preferred one,
+	// and removes its constraints. If there isn't a matching variant, the
+	// fallback is used as-is.
+	Collapse bool
+}
+
+// FilterLocales removes files with constraints which don't match the filter.
+// Directories which become empty are removed.
+func (w *Writer) FilterLocales(f LocaleFilter) {
+	filterLocales(w.root, f)
+}
+
+func filterLocales(d *writerNode, f LocaleFilter) {
+	var children []*writerNode
+	best := map[string]int{} // index into children
+	for _, c := range d.children {
+		if c.dir {
+			if len(c.children) != 0 {
+				filterLocales(c, f)
+				if len(c.children) == 0 {
+					continue // emptied by the filter
+				}
+			}
+			children = append(children, c)
+			continue
+		}
+
+		lr, cr, ok := f.rank(c.country, c.language)
+		if !ok {
+			continue
+		}
+		if !f.Collapse {
+			children = append(children, c)
+			continue
+		}
+
+		if i, ok := best[c.name]; ok {
+			if blr, bcr, _ := f.rank(children[i].country, children[i].language); lr < blr || (lr == blr && cr < bcr) {
+				children[i] = c
+			}
+		} else {
+			best[c.name] = len(children)
+			children = append(children, c)
+		}
+	}
+	if f.Collapse {
+		for _, i := range best {
+			children[i].country = CountryAnyCountry
+			children[i].language = LanguageC
+		}
+	}
+	d.children = children
+}
+
+// rank returns the preference of the language and country (lower is better),
+// and whether they match the filter at all.
+func (f LocaleFilter) rank(country Country, language Language) (int, int, bool) {
+	lr, cr := -1, -1
+	if language == LanguageAnyLanguage || language == LanguageC {
+		lr = len(f.Languages)
+	} else {
+		for i, x := range f.Languages {
+			if x == language {
+				lr = i
+				break
+			}
+		}
+	}
+	if country == CountryAnyCountry {
+		cr = len(f.Countries)
+	} else if len(f.Countries) == 0 {
+		cr = 0
+	} else {
+		for i, x := range f.Countries {
+			if x == country {
+				cr = i
+				break
+			}
+		}
+	}
+	return lr, cr, lr != -1 && cr != -1
+}
@@ -0,0 +1,63 @@
+package qrc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterLocales(t *testing.T) {
+	build := func() *Writer {
+		w := NewWriter()
+		for _, f := range []struct {
+			p string
+			c Country
+			l Language
+		}{
+			{"a.txt", CountryAnyCountry, LanguageC},
+			{"a.txt", CountryAnyCountry, LanguageFrench},
+			{"a.txt", CountryCanada, LanguageFrench},
+			{"a.txt", CountryAnyCountry, LanguageGerman},
+			{"b.txt", CountryAnyCountry, LanguageAnyLanguage},
+			{"c.txt", CountryAnyCountry, LanguageGerman},
+			{"x/d.txt", CountryAnyCountry, LanguageGerman},
+		} {
+			if err := w.Add(f.p, f.c, f.l, time.Time{}, []byte(FormatConstraints(f.p, f.c, f.l))); err != nil {
+				t.Fatalf("add: %v", err)
+			}
+		}
+		return w
+	}
+
+	w := build()
+	w.FilterLocales(LocaleFilter{Languages: []Language{LanguageFrench}})
+	testFilesEqual(t, testReader(t, w, 2), map[string]string{
+		"a.txt":                                  "a.txt",
+		"a[language!French].txt":                 "a[language!French].txt",
+		"a[country!Canada][language!French].txt": "a[country!Canada][language!French].txt",
+		"b.txt":                                  "b.txt",
+	})
+
+	w = build()
+	w.FilterLocales(LocaleFilter{Languages: []Language{LanguageFrench}, Countries: []Country{CountryFrance}})
+	testFilesEqual(t, testReader(t, w, 2), map[string]string{
+		"a.txt":                  "a.txt",
+		"a[language!French].txt": "a[language!French].txt",
+		"b.txt":                  "b.txt",
+	})
+
+	w = build()
+	w.FilterLocales(LocaleFilter{Languages: []Language{LanguageFrench, LanguageGerman}, Countries: []Country{CountryCanada}, Collapse: true})
+	testFilesEqual(t, testReader(t, w, 2), map[string]string{
+		"a.txt":   "a[country!Canada][language!French].txt",
+		"b.txt":   "b.txt",
+		"c.txt":   "c[language!German].txt",
+		"x/d.txt": "x/d[language!German].txt",
+	})
+
+	w = build()
+	w.FilterLocales(LocaleFilter{Collapse: true})
+	testFilesEqual(t, testReader(t, w, 2), map[string]string{
+		"a.txt": "a.txt",
+		"b.txt": "b.txt",
+	})
+}
@@ -0,0 +1,237 @@
+package qrc
+
+import (
+	"container/list"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// pathIndex caches directory listings by path for Lookup, Stat, and Open. The
+// least recently used listings are evicted when the total number of cached
+// entries would exceed the limit. It is safe for concurrent use. A nil
+// *pathIndex caches nothing.
+type pathIndex struct {
+	mu   sync.Mutex
+	max  int
+	n    int
+	lru  *list.List // *indexDir, most recently used first
+	dirs map[string]*list.Element
+}
+
+// indexDir is a cached directory listing.
+type indexDir struct {
+	path     string
+	entry    *ReaderEntry
+	children map[string][]*ReaderEntry // by name, in tree order
+	n        int
+}
+
+func newPathIndex(max int) *pathIndex {
+	return &pathIndex{
+		max:  max,
+		lru:  list.New(),
+		dirs: map[string]*list.Element{},
+	}
+}
+
+func (x *pathIndex) get(p string) (*indexDir, bool) {
+	if x == nil {
+		return nil, false
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if el, ok := x.dirs[p]; ok {
+		x.lru.MoveToFront(el)
+		return el.Value.(*indexDir), true
+	}
+	return nil, false
+}
+
+func (x *pathIndex) put(d *indexDir) {
+	if x == nil || d.n > x.max {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if el, ok := x.dirs[d.path]; ok {
+		x.n -= el.Value.(*indexDir).n
+		x.lru.Remove(el)
+	}
+	for x.n+d.n > x.max {
+		el := x.lru.Back()
+		x.n -= el.Value.(*indexDir).n
+		delete(x.dirs, el.Value.(*indexDir).path)
+		x.lru.Remove(el)
+	}
+	x.dirs[d.path] = x.lru.PushFront(d)
+	x.n += d.n
+}
+
+func (x *pathIndex) full() bool {
+	if x == nil {
+		return true
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.n >= x.max
+}
+
+// buildIndex fills the index with directory listings in breadth-first order
+// until it is full. Errors are ignored, since they will be returned when the
+// path is looked up.
+func (r *Reader) buildIndex() {
+	q := []string{""}
+	for len(q) != 0 && !r.index.full() {
+		d, err := r.dir(q[0])
+		q = q[1:]
+		if err != nil {
+			continue
+		}
+		for _, c := range d.children {
+			for _, e := range c {
+				if e.IsDir() {
+					q = append(q, e.p)
+					break
+				}
+			}
+		}
+	}
+}
+
+// dir gets the listing for the directory at the cleaned path p.
+func (r *Reader) dir(p string) (*indexDir, error) {
+	if d, ok := r.index.get(p); ok {
+		return d, nil
+	}
+
+	var e *ReaderEntry
+	if p == "" {
+		e = &ReaderEntry{n: r.root, r: r}
+	} else {
+		pp, name := splitLast(p)
+		pd, err := r.dir(pp)
+		if err != nil {
+			return nil, err
+		}
+		for _, x := range pd.children[name] {
+			if x.IsDir() {
+				e = x
+				break
+			}
+		}
+		if e == nil {
+			if len(pd.children[name]) != 0 {
+				return nil, pd.children[name][0].error("tree", r.treeOffset+int64(pd.children[name][0].i)*nodeSize(r.format), ErrNotDir)
+			}
+			return nil, pd.notExist(name)
+		}
+	}
+
+	c, err := e.Children()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &indexDir{
+		path:     p,
+		entry:    e,
+		children: make(map[string][]*ReaderEntry, len(c)),
+		n:        len(c),
+	}
+	for _, x := range c {
+		d.children[x.v] = append(d.children[x.v], x)
+	}
+	r.index.put(d)
+	return d, nil
+}
+
+func (d *indexDir) notExist(name string) error {
+	e := *d.entry
+	e.p = strings.TrimLeft(d.path+"/"+name, "/")
+	return e.error("tree", e.r.treeOffset+e.n.dirTreeOffset(), os.ErrNotExist)
+}
+
+// Lookup returns all variants of the entry at the path (i.e. files with the
+// same name, but different country/language constraints) in tree order. The
+// path is separated with forward slashes, and a leading slash is optional. If
+// the entry does not exist, an *Error wrapping os.ErrNotExist is returned.
+//
+// The directory listings are cached (see ReaderOptions.IndexSize), so repeated
+// lookups in the same directory don't need to read the tree again.
+func (r *Reader) Lookup(name string) ([]*ReaderEntry, error) {
+	p := strings.Trim(path.Clean("/"+name), "/")
+	if p == "" {
+		return []*ReaderEntry{{n: r.root, r: r}}, nil
+	}
+	pp, base := splitLast(p)
+	d, err := r.dir(pp)
+	if err != nil {
+		return nil, err
+	}
+	if c := d.children[base]; len(c) != 0 {
+		return append([]*ReaderEntry(nil), c...), nil
+	}
+	return nil, d.notExist(base)
+}
+
+// Stat returns the entry at the path, choosing the variant for the locale in
+// the same way as QResource: a file matching the country and language is
+// preferred, followed by one matching the language for any country, and then
+// one for LanguageC and any country. If there isn't a matching variant, an
+// *Error wrapping os.ErrNotExist is returned.
+func (r *Reader) Stat(name string, country Country, language Language) (*ReaderEntry, error) {
+	c, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	var match *ReaderEntry
+	for _, e := range c {
+		if e.IsDir() {
+			return e, nil
+		}
+		switch ec, el := e.Constraints(); {
+		case ec == country && el == language:
+			return e, nil
+		case ec == CountryAnyCountry && el == language:
+			match = e
+		case ec == CountryAnyCountry && el == LanguageC && match == nil:
+			match = e
+		}
+	}
+	if match == nil {
+		e := *c[0]
+		return nil, e.error("tree", r.treeOffset+int64(e.i)*nodeSize(r.format), os.ErrNotExist)
+	}
+	return match, nil
+}
+
+// Open opens the file at the path, choosing the variant for the locale like
+// Stat.
+func (r *Reader) Open(name string, country Country, language Language) (io.ReadCloser, error) {
+	e, err := r.Stat(name, country, language)
+	if err != nil {
+		return nil, err
+	}
+	return e.Open()
+}
+
+// OpenFile is like Open, but returns a *ReaderFile for random access (see
+// ReaderEntry.OpenFile).
+func (r *Reader) OpenFile(name string, country Country, language Language) (*ReaderFile, error) {
+	e, err := r.Stat(name, country, language)
+	if err != nil {
+		return nil, err
+	}
+	return e.OpenFile()
+}
+
+// splitLast splits a cleaned path into the parent and the last component.
+func splitLast(p string) (string, string) {
+	if i := strings.LastIndexByte(p, '/'); i != -1 {
+		return p[:i], p[i+1:]
+	}
+	return "", p
+}
@@ -0,0 +1,123 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIndex(t *testing.T) {
+	w := testWriter(t)
+	w.Add("i18n/hello.txt", CountryAnyCountry, LanguageGerman, time.Time{}, []byte("hallo"))
+	for i := 0; i < 10; i++ {
+		w.Add(fmt.Sprintf("dir%d/sub/file.txt", i), CountryAnyCountry, LanguageC, time.Time{}, []byte(fmt.Sprint(i)))
+	}
+	var buf bytes.Buffer
+	if err := w.WriteRCC(&buf, 2); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+
+	for _, opt := range []ReaderOptions{
+		{},
+		{IndexSize: -1},
+		{IndexSize: 3},
+		{IndexEager: true},
+	} {
+		t.Run(fmt.Sprintf("IndexSize=%d,IndexEager=%t", opt.IndexSize, opt.IndexEager), func(t *testing.T) {
+			opt := opt
+			r, err := NewReaderFromRCCWithOptions(bytes.NewReader(buf.Bytes()), &opt)
+			if err != nil {
+				t.Fatalf("read rcc: %v", err)
+			}
+			if opt.IndexSize < 0 && r.index != nil {
+				t.Errorf("expected index to be disabled")
+			}
+			if opt.IndexEager && r.index.n != 37 {
+				t.Errorf("expected eager index to contain all 37 entries, got %d", r.index.n)
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 10; j++ {
+						testIndex(t, r)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if r.index != nil && r.index.n > r.opt.IndexSize {
+				t.Errorf("index contains %d entries, which is larger than the limit %d", r.index.n, r.opt.IndexSize)
+			}
+		})
+	}
+}
+
+func testIndex(t *testing.T, r *Reader) {
+	if c, err := r.Lookup("/i18n/hello.txt"); err != nil {
+		t.Errorf("lookup: unexpected error: %v", err)
+	} else if len(c) != 3 {
+		t.Errorf("lookup: expected 3 variants, got %d", len(c))
+	}
+	if c, err := r.Lookup(""); err != nil {
+		t.Errorf("lookup root: unexpected error: %v", err)
+	} else if len(c) != 1 || !c[0].IsDir() || c[0].p != "" {
+		t.Errorf("lookup root: incorrect result")
+	}
+	if e, err := r.Stat("dir3/sub", CountryAnyCountry, LanguageC); err != nil {
+		t.Errorf("stat dir: unexpected error: %v", err)
+	} else if !e.IsDir() || e.p != "dir3/sub" {
+		t.Errorf("stat dir: incorrect result %q", e.p)
+	}
+
+	for _, c := range []struct {
+		path     string
+		country  Country
+		language Language
+		exp      string
+	}{
+		{"i18n/hello.txt", CountryAnyCountry, LanguageC, "hello"},
+		{"/i18n/hello.txt", CountryCanada, LanguageFrench, "bonjour"},
+		{"i18n/hello.txt", CountryFrance, LanguageFrench, "hello"},
+		{"i18n/hello.txt", CountryGermany, LanguageGerman, "hallo"},
+		{"i18n//./hello.txt/", CountryAnyCountry, LanguageGerman, "hallo"},
+		{"dir7/sub/file.txt", CountryCanada, LanguageFrench, "7"},
+		{"main.qml", CountryAnyCountry, LanguageC, "import QtQuick 2.0\n\nItem {}\n"},
+	} {
+		rc, err := r.Open(c.path, c.country, c.language)
+		if err != nil {
+			t.Errorf("open %q (%s, %s): unexpected error: %v", c.path, c.country, c.language, err)
+			continue
+		}
+		buf, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Errorf("open %q (%s, %s): read: unexpected error: %v", c.path, c.country, c.language, err)
+		} else if string(buf) != c.exp {
+			t.Errorf("open %q (%s, %s): expected %q, got %q", c.path, c.country, c.language, c.exp, string(buf))
+		}
+	}
+
+	for _, c := range []struct {
+		path string
+		err  error
+	}{
+		{"nonexistent.txt", os.ErrNotExist},
+		{"i18n/nonexistent.txt", os.ErrNotExist},
+		{"nonexistent/hello.txt", os.ErrNotExist},
+		{"main.qml/hello.txt", ErrNotDir},
+	} {
+		if _, err := r.Lookup(c.path); !errors.Is(err, c.err) {
+			t.Errorf("lookup %q: expected error %v, got %v", c.path, c.err, err)
+		} else if e := (*Error)(nil); !errors.As(err, &e) || e.Region != "tree" {
+			t.Errorf("lookup %q: expected *Error in the tree region, got %#v", c.path, err)
+		}
+	}
+}
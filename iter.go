@@ -0,0 +1,271 @@
+package qrc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// IterOrder is the order in which an Iterator visits entries.
+type IterOrder int
+
+const (
+	// IterTreeOrder visits the entries depth-first in the order they are
+	// stored in the tree, like Walk.
+	IterTreeOrder IterOrder = iota
+
+	// IterDataOrder visits the directories of each RCC file depth-first, then
+	// the files in the order of their data, which makes reading the contents
+	// of all files sequential. Nested RCC files are expanded where their data
+	// is.
+	IterDataOrder
+)
+
+// Iterator visits the entries of a Reader one at a time, like tar.Reader. It
+// is an alternative to Walk which doesn't need a callback, so it can be
+// stopped at any point. It is not safe for concurrent use.
+type Iterator struct {
+	order  IterOrder
+	rcc    bool
+	nodes  int
+	stack  []*iterFrame
+	cur    *iterEntry
+	pushed bool // whether a frame was pushed for cur
+	f      *ReaderFile
+	err    error // sticky
+}
+
+type iterFrame struct {
+	entries []iterEntry
+	i       int
+	r       *Reader  // nested rcc to close when done, or nil
+	skip    []string // prefixes of skipped directories, for IterDataOrder
+}
+
+type iterEntry struct {
+	path  string
+	entry *ReaderEntry
+	err   error // for IterDataOrder, from reading the children of a directory
+}
+
+// Iter returns an Iterator over the entries in the tree. If rccRecurse is
+// true, nested RCC files (see ReaderOptions.NestedSniff and
+// ReaderOptions.NestedFunc) are opened and treated as a directory, like Walk.
+// The Iterator should be closed when done to release any nested RCC files.
+func (r *Reader) Iter(rccRecurse bool, order IterOrder) *Iterator {
+	it := &Iterator{
+		order: order,
+		rcc:   rccRecurse,
+	}
+	if err := it.push("", &ReaderEntry{n: r.root, r: r}, nil); err != nil {
+		it.err = err
+	}
+	return it
+}
+
+// Next advances to the next entry, returning it along with the path, which is
+// the same as the one passed to a WalkFunc. For nested RCC files, the root
+// directory of the nested Reader is returned instead of the file. If a
+// directory can't be read or a nested RCC file can't be opened, the entry is
+// returned with the error, and it is treated as an empty directory or regular
+// file. Other errors stop the iteration. At the end, io.EOF is returned.
+func (it *Iterator) Next() (*ReaderEntry, string, error) {
+	if it.f != nil {
+		it.f.Close()
+		it.f = nil
+	}
+	it.cur, it.pushed = nil, false
+	if it.err != nil {
+		return nil, "", it.err
+	}
+	for len(it.stack) != 0 {
+		fr := it.stack[len(it.stack)-1]
+		if fr.i >= len(fr.entries) {
+			it.pop()
+			continue
+		}
+		x := &fr.entries[fr.i]
+		fr.i++
+		if fr.skipped(x.path) {
+			continue
+		}
+		if it.order == IterTreeOrder {
+			it.nodes++ // otherwise, walk counts them
+			if max := x.entry.r.opt.MaxNodes; max >= 0 && it.nodes > max {
+				it.err = fmt.Errorf("iter %q: %w (%d)", x.path, ErrMaxNodes, max)
+				return nil, "", it.err
+			}
+		}
+		it.cur = x
+		if x.err != nil {
+			return x.entry, x.path, x.err
+		}
+
+		if x.entry.IsDir() {
+			if it.order == IterTreeOrder {
+				if err := it.push(x.path, x.entry, nil); err != nil {
+					return x.entry, x.path, fmt.Errorf("iter: get children for dir %q: %w", x.path, err)
+				}
+			}
+			return x.entry, x.path, nil
+		}
+
+		if it.rcc {
+			// attempt to open the rcc (this also checks the nesting limit)
+			r, err := x.entry.OpenNestedRCC(x.path)
+			if err != nil {
+				return x.entry, x.path, fmt.Errorf("iter: open nested rcc %q: %w", x.path, err)
+			}
+			if r != nil {
+				root := &ReaderEntry{
+					v: x.entry.v,
+					p: x.entry.p,
+					n: r.root,
+					r: r,
+				}
+				if err := it.push(x.path, root, r); err != nil {
+					r.Close()
+					if errors.Is(err, ErrMaxNodes) {
+						it.err = err
+						return nil, "", err
+					}
+					return x.entry, x.path, fmt.Errorf("iter: read nested rcc %q: %w", x.path, err)
+				}
+				it.cur = &iterEntry{path: x.path, entry: root}
+				return root, x.path, nil
+			}
+		}
+
+		return x.entry, x.path, nil
+	}
+	it.err = io.EOF
+	return nil, "", it.err
+}
+
+// SkipDir skips the contents of the current entry if it is a directory
+// (including the root of a nested RCC file), like returning filepath.SkipDir
+// from a WalkFunc. Otherwise, it does nothing.
+func (it *Iterator) SkipDir() {
+	if it.cur == nil || !it.cur.entry.IsDir() {
+		return
+	}
+	if it.pushed {
+		it.pop()
+		it.pushed = false
+	} else if len(it.stack) != 0 {
+		fr := it.stack[len(it.stack)-1]
+		fr.skip = append(fr.skip, it.cur.path+"/")
+	}
+}
+
+// Read reads from the contents of the current file. If the current entry is a
+// directory, io.EOF is returned. Errors are returned as an *Error.
+func (it *Iterator) Read(p []byte) (int, error) {
+	if it.cur == nil || it.cur.entry.IsDir() {
+		return 0, io.EOF
+	}
+	if it.f == nil {
+		f, err := it.cur.entry.OpenFile()
+		if err != nil {
+			return 0, err
+		}
+		it.f = f
+	}
+	return it.f.Read(p)
+}
+
+// Close releases the current file and any nested RCC files. Afterwards, Next
+// returns io.EOF.
+func (it *Iterator) Close() error {
+	var err error
+	if it.f != nil {
+		err = it.f.Close()
+		it.f = nil
+	}
+	for len(it.stack) != 0 {
+		if perr := it.pop(); err == nil {
+			err = perr
+		}
+	}
+	it.cur, it.err = nil, io.EOF
+	return err
+}
+
+// push adds a frame with the contents of dir, which is the root directory of r
+// if r is not nil. For IterDataOrder, dir must be the root directory of a
+// Reader.
+func (it *Iterator) push(path string, dir *ReaderEntry, r *Reader) error {
+	fr := &iterFrame{r: r}
+	if it.order == IterTreeOrder {
+		c, err := dir.Children()
+		if err != nil {
+			return err
+		}
+		fr.entries = make([]iterEntry, len(c))
+		for i, e := range c {
+			fr.entries[i] = iterEntry{
+				path:  strings.TrimLeft(path+"/"+e.Name(), "/"),
+				entry: e,
+			}
+		}
+	} else {
+		entries, err := dataOrder(path, dir, &it.nodes)
+		if err != nil {
+			return err
+		}
+		fr.entries = entries
+	}
+	it.stack = append(it.stack, fr)
+	it.pushed = true
+	return nil
+}
+
+// dataOrder returns the entries below dir, which must be the root directory of
+// a Reader, in the order used by IterDataOrder and StreamReader: the
+// directories depth-first, then the files in the order of their data. Errors
+// reading directories are returned with the entry. The paths are prefixed with
+// path.
+func dataOrder(path string, dir *ReaderEntry, nodes *int) ([]iterEntry, error) {
+	var dirs, files []iterEntry
+	if err := walk(func(p string, entry *ReaderEntry, err error) error {
+		x := iterEntry{
+			path:  strings.TrimLeft(path+"/"+p, "/"),
+			entry: entry,
+			err:   err,
+		}
+		if entry.IsDir() {
+			dirs = append(dirs, x)
+		} else {
+			files = append(files, x)
+		}
+		return nil
+	}, false, nodes, "", dir); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].entry.n.DataOffset < files[j].entry.n.DataOffset
+	})
+	return append(dirs, files...), nil
+}
+
+// pop removes the top frame, closing the nested RCC file if any.
+func (it *Iterator) pop() error {
+	fr := it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	if fr.r != nil {
+		return fr.r.Close()
+	}
+	return nil
+}
+
+// skipped checks whether the path is in a skipped directory.
+func (fr *iterFrame) skipped(path string) bool {
+	for _, p := range fr.skip {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
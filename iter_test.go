@@ -0,0 +1,176 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	build := testBuildRCC(t)
+	inner := build(CompressionNone, map[string][]byte{
+		"x.txt":   []byte("x"),
+		"y/z.txt": bytes.Repeat([]byte("z"), 1000),
+	})
+	outer := build(CompressionZlib, map[string][]byte{
+		"a.txt":         []byte("a"),
+		"b/c.txt":       bytes.Repeat([]byte("c"), 1000),
+		"b/d/e.txt":     testSeekData(10000),
+		"b/f.rcc":       inner,
+		"g/h.txt":       []byte("h"),
+		"g/stored.rcc":  build(CompressionNone, map[string][]byte{"i.txt": []byte("i")}),
+		"g/j/bad.rcc":   []byte("qres"),
+		"g/j/k/l/m.txt": []byte("m"),
+		"n.rcc":         inner,
+	})
+
+	dir, err := ioutil.TempDir("", "qrc")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := NewReaderFromRCCWithOptions(bytes.NewReader(outer), &ReaderOptions{
+		SpillSize: 10,
+		SpillDir:  dir,
+	})
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+
+	for _, rccRecurse := range []bool{false, true} {
+		var walked []string
+		if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+			if err != nil {
+				path += "!"
+			}
+			walked = append(walked, path)
+			return nil
+		}, rccRecurse); err != nil {
+			t.Fatalf("walk: %v", err)
+		}
+
+		for _, order := range []IterOrder{IterTreeOrder, IterDataOrder} {
+			what := map[IterOrder]string{IterTreeOrder: "tree", IterDataOrder: "data"}[order]
+			if rccRecurse {
+				what += " recursive"
+			}
+
+			var (
+				paths []string
+				last  = map[*Reader]int64{}
+				files = map[string]string{}
+			)
+			it := r.Iter(rccRecurse, order)
+			for {
+				e, p, err := it.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if e == nil {
+						t.Fatalf("%s: next: unexpected error: %v", what, err)
+					}
+					p += "!"
+				} else if !e.IsDir() {
+					if order == IterDataOrder {
+						if e.Offset() < last[e.Reader()] {
+							t.Errorf("%s: %q: expected files in data order", what, p)
+						}
+						last[e.Reader()] = e.Offset()
+					}
+					b, err := ioutil.ReadAll(it)
+					if err != nil {
+						t.Errorf("%s: %q: read: unexpected error: %v", what, p, err)
+					}
+					files[p] = string(b)
+				}
+				paths = append(paths, p)
+			}
+			if err := it.Close(); err != nil {
+				t.Errorf("%s: close: unexpected error: %v", what, err)
+			}
+
+			if order == IterTreeOrder {
+				if !reflect.DeepEqual(paths, walked) {
+					t.Errorf("%s: expected same order as walk %q, got %q", what, walked, paths)
+				}
+			} else {
+				if !reflect.DeepEqual(testSorted(paths), testSorted(walked)) {
+					t.Errorf("%s: expected same entries as walk %q, got %q", what, walked, paths)
+				}
+			}
+			if files["a.txt"] != "a" || files["b/d/e.txt"] != string(testSeekData(10000)) {
+				t.Errorf("%s: incorrect file contents", what)
+			}
+			if rccRecurse && files["b/f.rcc/y/z.txt"] != strings.Repeat("z", 1000) {
+				t.Errorf("%s: incorrect nested file contents", what)
+			}
+			if fs, _ := ioutil.ReadDir(dir); len(fs) != 0 {
+				t.Errorf("%s: expected temp files to be removed, got %d files", what, len(fs))
+			}
+
+			// skip some dirs, and stop in the middle of a nested rcc
+			var skipped []string
+			it = r.Iter(rccRecurse, order)
+			for {
+				e, p, err := it.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					continue
+				}
+				skipped = append(skipped, p)
+				if p == "b" || p == "g/stored.rcc" || p == "g/j/k" {
+					it.SkipDir()
+				}
+				if p == "n.rcc" && e.IsDir() {
+					if fs, _ := ioutil.ReadDir(dir); len(fs) != 1 {
+						t.Errorf("%s: expected temp file for nested rcc, got %d files", what, len(fs))
+					}
+					break
+				}
+			}
+			for _, p := range skipped {
+				if strings.HasPrefix(p, "b/") || strings.HasPrefix(p, "g/stored.rcc/") || strings.HasPrefix(p, "g/j/k/") {
+					t.Errorf("%s: expected %q to be skipped", what, p)
+				}
+			}
+			if err := it.Close(); err != nil {
+				t.Errorf("%s: close: unexpected error: %v", what, err)
+			}
+			if fs, _ := ioutil.ReadDir(dir); len(fs) != 0 {
+				t.Errorf("%s: expected temp files to be removed after close, got %d files", what, len(fs))
+			}
+			if _, _, err := it.Next(); err != io.EOF {
+				t.Errorf("%s: expected io.EOF after close, got %v", what, err)
+			}
+		}
+	}
+
+	r.opt.MaxNodes = 5
+	for _, order := range []IterOrder{IterTreeOrder, IterDataOrder} {
+		it := r.Iter(true, order)
+		var err error
+		for err != io.EOF && !errors.Is(err, ErrMaxNodes) {
+			_, _, err = it.Next()
+		}
+		if err == io.EOF {
+			t.Errorf("expected max nodes error")
+		}
+		it.Close()
+	}
+}
+
+func testSorted(s []string) []string {
+	s = append([]string(nil), s...)
+	sort.Strings(s)
+	return s
+}
@@ -102,6 +102,14 @@ func generate() error {
 		return err
 	}
 
+	if err := language.GenerateGoParseTest(tf, "Language"); err != nil {
+		return err
+	}
+
+	if err := country.GenerateGoParseTest(tf, "Country"); err != nil {
+		return err
+	}
+
 	if err := tf.Close(); err != nil {
 		return fmt.Errorf("close temp file: %w", err)
 	}
@@ -307,6 +315,18 @@ func (e Enum) GenerateGo(w io.Writer, typeName, typeType string) error {
 		return err
 	}
 
+	if _, err := fmt.Fprintf(w, "\n// Parse%s returns the %s with the provided name (which may be an\n// alias). If there isn't one, false is returned.\nfunc Parse%s(s string) (%s, bool) {\n\tswitch s {\n", typeName, typeName, typeName, typeName); err != nil {
+		return err
+	}
+	for _, n := range v {
+		if _, err := fmt.Fprintf(w, "\tcase %#v:\n\t\treturn %s%s, true\n", n, typeName, n); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "\t}\n\treturn 0, false\n}\n"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -340,3 +360,35 @@ func (e Enum) GenerateGoTest(w io.Writer, typeName string) error {
 	}
 	return nil
 }
+
+func (e Enum) GenerateGoParseTest(w io.Writer, typeName string) error {
+	var x []struct {
+		C int
+		N string
+	}
+	for n, c := range e.Value { // including aliases
+		x = append(x, struct {
+			C int
+			N string
+		}{c, n})
+	}
+	sort.Slice(x, func(i, j int) bool {
+		return x[i].C < x[j].C
+	})
+
+	if _, err := fmt.Fprintf(w, "\nfunc TestParse%s(t *testing.T) {\n", typeName); err != nil {
+		return err
+	}
+	for _, c := range x {
+		if _, err := fmt.Fprintf(w, "\tif v, ok := Parse%s(%#v); !ok || v != %s%s {\n\t\tt.Errorf(%#v, %#v)\n\t}\n", typeName, c.N, typeName, c.N, "%q incorrect", c.N); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "\tif _, ok := Parse%s(%#v); ok {\n\t\tt.Errorf(%#v, %#v)\n\t}\n", typeName, "Invalid", "%q incorrect", "Invalid"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "}\n"); err != nil {
+		return err
+	}
+	return nil
+}
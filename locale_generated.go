@@ -1124,6 +1124,774 @@ func (l Language) String() string {
 	panic("no such language")
 }
 
+// ParseLanguage returns the Language with the provided name (which may be an
+// alias). If there isn't one, false is returned.
+func ParseLanguage(s string) (Language, bool) {
+	switch s {
+	case "Abkhazian":
+		return LanguageAbkhazian, true
+	case "Afan":
+		return LanguageAfan, true
+	case "Afar":
+		return LanguageAfar, true
+	case "Afrikaans":
+		return LanguageAfrikaans, true
+	case "Aghem":
+		return LanguageAghem, true
+	case "Ahom":
+		return LanguageAhom, true
+	case "Akan":
+		return LanguageAkan, true
+	case "Akkadian":
+		return LanguageAkkadian, true
+	case "Akoose":
+		return LanguageAkoose, true
+	case "Albanian":
+		return LanguageAlbanian, true
+	case "AmericanSignLanguage":
+		return LanguageAmericanSignLanguage, true
+	case "Amharic":
+		return LanguageAmharic, true
+	case "AncientEgyptian":
+		return LanguageAncientEgyptian, true
+	case "AncientGreek":
+		return LanguageAncientGreek, true
+	case "AncientNorthArabian":
+		return LanguageAncientNorthArabian, true
+	case "AnyLanguage":
+		return LanguageAnyLanguage, true
+	case "Arabic":
+		return LanguageArabic, true
+	case "Aragonese":
+		return LanguageAragonese, true
+	case "Aramaic":
+		return LanguageAramaic, true
+	case "ArdhamagadhiPrakrit":
+		return LanguageArdhamagadhiPrakrit, true
+	case "Armenian":
+		return LanguageArmenian, true
+	case "Assamese":
+		return LanguageAssamese, true
+	case "Asturian":
+		return LanguageAsturian, true
+	case "Asu":
+		return LanguageAsu, true
+	case "Atsam":
+		return LanguageAtsam, true
+	case "Avaric":
+		return LanguageAvaric, true
+	case "Avestan":
+		return LanguageAvestan, true
+	case "Aymara":
+		return LanguageAymara, true
+	case "Azerbaijani":
+		return LanguageAzerbaijani, true
+	case "Bafia":
+		return LanguageBafia, true
+	case "Balinese":
+		return LanguageBalinese, true
+	case "Bambara":
+		return LanguageBambara, true
+	case "Bamun":
+		return LanguageBamun, true
+	case "Basaa":
+		return LanguageBasaa, true
+	case "Bashkir":
+		return LanguageBashkir, true
+	case "Basque":
+		return LanguageBasque, true
+	case "Bassa":
+		return LanguageBassa, true
+	case "BatakToba":
+		return LanguageBatakToba, true
+	case "Belarusian":
+		return LanguageBelarusian, true
+	case "Bemba":
+		return LanguageBemba, true
+	case "Bena":
+		return LanguageBena, true
+	case "Bengali":
+		return LanguageBengali, true
+	case "Bhojpuri":
+		return LanguageBhojpuri, true
+	case "Bhutani":
+		return LanguageBhutani, true
+	case "Bihari":
+		return LanguageBihari, true
+	case "Bislama":
+		return LanguageBislama, true
+	case "Blin":
+		return LanguageBlin, true
+	case "Bodo":
+		return LanguageBodo, true
+	case "Bosnian":
+		return LanguageBosnian, true
+	case "Breton":
+		return LanguageBreton, true
+	case "Buginese":
+		return LanguageBuginese, true
+	case "Buhid":
+		return LanguageBuhid, true
+	case "Bulgarian":
+		return LanguageBulgarian, true
+	case "Burmese":
+		return LanguageBurmese, true
+	case "Byelorussian":
+		return LanguageByelorussian, true
+	case "C":
+		return LanguageC, true
+	case "Cambodian":
+		return LanguageCambodian, true
+	case "Cantonese":
+		return LanguageCantonese, true
+	case "Carian":
+		return LanguageCarian, true
+	case "Catalan":
+		return LanguageCatalan, true
+	case "CentralKurdish":
+		return LanguageCentralKurdish, true
+	case "CentralMoroccoTamazight":
+		return LanguageCentralMoroccoTamazight, true
+	case "Chakma":
+		return LanguageChakma, true
+	case "Chamorro":
+		return LanguageChamorro, true
+	case "Chechen":
+		return LanguageChechen, true
+	case "Cherokee":
+		return LanguageCherokee, true
+	case "Chewa":
+		return LanguageChewa, true
+	case "Chiga":
+		return LanguageChiga, true
+	case "Chinese":
+		return LanguageChinese, true
+	case "Church":
+		return LanguageChurch, true
+	case "Chuvash":
+		return LanguageChuvash, true
+	case "ClassicalMandaic":
+		return LanguageClassicalMandaic, true
+	case "Colognian":
+		return LanguageColognian, true
+	case "CongoSwahili":
+		return LanguageCongoSwahili, true
+	case "Coptic":
+		return LanguageCoptic, true
+	case "Cornish":
+		return LanguageCornish, true
+	case "Corsican":
+		return LanguageCorsican, true
+	case "Cree":
+		return LanguageCree, true
+	case "Croatian":
+		return LanguageCroatian, true
+	case "Czech":
+		return LanguageCzech, true
+	case "Danish":
+		return LanguageDanish, true
+	case "Divehi":
+		return LanguageDivehi, true
+	case "Dogri":
+		return LanguageDogri, true
+	case "Duala":
+		return LanguageDuala, true
+	case "Dutch":
+		return LanguageDutch, true
+	case "Dzongkha":
+		return LanguageDzongkha, true
+	case "EasternCham":
+		return LanguageEasternCham, true
+	case "EasternKayah":
+		return LanguageEasternKayah, true
+	case "Embu":
+		return LanguageEmbu, true
+	case "English":
+		return LanguageEnglish, true
+	case "Esperanto":
+		return LanguageEsperanto, true
+	case "Estonian":
+		return LanguageEstonian, true
+	case "Etruscan":
+		return LanguageEtruscan, true
+	case "Ewe":
+		return LanguageEwe, true
+	case "Ewondo":
+		return LanguageEwondo, true
+	case "Faroese":
+		return LanguageFaroese, true
+	case "Fijian":
+		return LanguageFijian, true
+	case "Filipino":
+		return LanguageFilipino, true
+	case "Finnish":
+		return LanguageFinnish, true
+	case "French":
+		return LanguageFrench, true
+	case "Frisian":
+		return LanguageFrisian, true
+	case "Friulian":
+		return LanguageFriulian, true
+	case "Fulah":
+		return LanguageFulah, true
+	case "Ga":
+		return LanguageGa, true
+	case "Gaelic":
+		return LanguageGaelic, true
+	case "Galician":
+		return LanguageGalician, true
+	case "Ganda":
+		return LanguageGanda, true
+	case "Geez":
+		return LanguageGeez, true
+	case "Georgian":
+		return LanguageGeorgian, true
+	case "German":
+		return LanguageGerman, true
+	case "Gothic":
+		return LanguageGothic, true
+	case "Greek":
+		return LanguageGreek, true
+	case "Greenlandic":
+		return LanguageGreenlandic, true
+	case "Guarani":
+		return LanguageGuarani, true
+	case "Gujarati":
+		return LanguageGujarati, true
+	case "Gusii":
+		return LanguageGusii, true
+	case "Haitian":
+		return LanguageHaitian, true
+	case "Hanunoo":
+		return LanguageHanunoo, true
+	case "Hausa":
+		return LanguageHausa, true
+	case "Hawaiian":
+		return LanguageHawaiian, true
+	case "Hebrew":
+		return LanguageHebrew, true
+	case "Herero":
+		return LanguageHerero, true
+	case "HieroglyphicLuwian":
+		return LanguageHieroglyphicLuwian, true
+	case "Hindi":
+		return LanguageHindi, true
+	case "HiriMotu":
+		return LanguageHiriMotu, true
+	case "HmongNjua":
+		return LanguageHmongNjua, true
+	case "Ho":
+		return LanguageHo, true
+	case "Hungarian":
+		return LanguageHungarian, true
+	case "Icelandic":
+		return LanguageIcelandic, true
+	case "Ido":
+		return LanguageIdo, true
+	case "Igbo":
+		return LanguageIgbo, true
+	case "InariSami":
+		return LanguageInariSami, true
+	case "Indonesian":
+		return LanguageIndonesian, true
+	case "Ingush":
+		return LanguageIngush, true
+	case "Interlingua":
+		return LanguageInterlingua, true
+	case "Interlingue":
+		return LanguageInterlingue, true
+	case "Inuktitut":
+		return LanguageInuktitut, true
+	case "Inupiak":
+		return LanguageInupiak, true
+	case "Irish":
+		return LanguageIrish, true
+	case "Italian":
+		return LanguageItalian, true
+	case "Japanese":
+		return LanguageJapanese, true
+	case "Javanese":
+		return LanguageJavanese, true
+	case "Jju":
+		return LanguageJju, true
+	case "JolaFonyi":
+		return LanguageJolaFonyi, true
+	case "Kabuverdianu":
+		return LanguageKabuverdianu, true
+	case "Kabyle":
+		return LanguageKabyle, true
+	case "Kako":
+		return LanguageKako, true
+	case "Kalenjin":
+		return LanguageKalenjin, true
+	case "Kamba":
+		return LanguageKamba, true
+	case "Kannada":
+		return LanguageKannada, true
+	case "Kanuri":
+		return LanguageKanuri, true
+	case "Kashmiri":
+		return LanguageKashmiri, true
+	case "Kazakh":
+		return LanguageKazakh, true
+	case "Kenyang":
+		return LanguageKenyang, true
+	case "Khmer":
+		return LanguageKhmer, true
+	case "Kiche":
+		return LanguageKiche, true
+	case "Kikuyu":
+		return LanguageKikuyu, true
+	case "Kinyarwanda":
+		return LanguageKinyarwanda, true
+	case "Kirghiz":
+		return LanguageKirghiz, true
+	case "Komi":
+		return LanguageKomi, true
+	case "Kongo":
+		return LanguageKongo, true
+	case "Konkani":
+		return LanguageKonkani, true
+	case "Korean":
+		return LanguageKorean, true
+	case "Koro":
+		return LanguageKoro, true
+	case "KoyraChiini":
+		return LanguageKoyraChiini, true
+	case "KoyraboroSenni":
+		return LanguageKoyraboroSenni, true
+	case "Kpelle":
+		return LanguageKpelle, true
+	case "Kurdish":
+		return LanguageKurdish, true
+	case "Kurundi":
+		return LanguageKurundi, true
+	case "Kwanyama":
+		return LanguageKwanyama, true
+	case "Kwasio":
+		return LanguageKwasio, true
+	case "Lakota":
+		return LanguageLakota, true
+	case "Langi":
+		return LanguageLangi, true
+	case "Lao":
+		return LanguageLao, true
+	case "LargeFloweryMiao":
+		return LanguageLargeFloweryMiao, true
+	case "LastLanguage":
+		return LanguageLastLanguage, true
+	case "Latin":
+		return LanguageLatin, true
+	case "Latvian":
+		return LanguageLatvian, true
+	case "Lepcha":
+		return LanguageLepcha, true
+	case "Lezghian":
+		return LanguageLezghian, true
+	case "Limbu":
+		return LanguageLimbu, true
+	case "Limburgish":
+		return LanguageLimburgish, true
+	case "LinearA":
+		return LanguageLinearA, true
+	case "Lingala":
+		return LanguageLingala, true
+	case "Lisu":
+		return LanguageLisu, true
+	case "LiteraryChinese":
+		return LanguageLiteraryChinese, true
+	case "Lithuanian":
+		return LanguageLithuanian, true
+	case "Lojban":
+		return LanguageLojban, true
+	case "LowGerman":
+		return LanguageLowGerman, true
+	case "LowerSorbian":
+		return LanguageLowerSorbian, true
+	case "Lu":
+		return LanguageLu, true
+	case "LubaKatanga":
+		return LanguageLubaKatanga, true
+	case "LuleSami":
+		return LanguageLuleSami, true
+	case "Luo":
+		return LanguageLuo, true
+	case "Luxembourgish":
+		return LanguageLuxembourgish, true
+	case "Luyia":
+		return LanguageLuyia, true
+	case "Lycian":
+		return LanguageLycian, true
+	case "Lydian":
+		return LanguageLydian, true
+	case "Macedonian":
+		return LanguageMacedonian, true
+	case "Machame":
+		return LanguageMachame, true
+	case "Maithili":
+		return LanguageMaithili, true
+	case "MakhuwaMeetto":
+		return LanguageMakhuwaMeetto, true
+	case "Makonde":
+		return LanguageMakonde, true
+	case "Malagasy":
+		return LanguageMalagasy, true
+	case "Malay":
+		return LanguageMalay, true
+	case "Malayalam":
+		return LanguageMalayalam, true
+	case "Maltese":
+		return LanguageMaltese, true
+	case "Mandingo":
+		return LanguageMandingo, true
+	case "ManichaeanMiddlePersian":
+		return LanguageManichaeanMiddlePersian, true
+	case "Manipuri":
+		return LanguageManipuri, true
+	case "Manx":
+		return LanguageManx, true
+	case "Maori":
+		return LanguageMaori, true
+	case "Mapuche":
+		return LanguageMapuche, true
+	case "Marathi":
+		return LanguageMarathi, true
+	case "Marshallese":
+		return LanguageMarshallese, true
+	case "Masai":
+		return LanguageMasai, true
+	case "Mazanderani":
+		return LanguageMazanderani, true
+	case "Mende":
+		return LanguageMende, true
+	case "Meroitic":
+		return LanguageMeroitic, true
+	case "Meru":
+		return LanguageMeru, true
+	case "Meta":
+		return LanguageMeta, true
+	case "Mohawk":
+		return LanguageMohawk, true
+	case "Moldavian":
+		return LanguageMoldavian, true
+	case "Mongolian":
+		return LanguageMongolian, true
+	case "Mono":
+		return LanguageMono, true
+	case "Morisyen":
+		return LanguageMorisyen, true
+	case "Mru":
+		return LanguageMru, true
+	case "Mundang":
+		return LanguageMundang, true
+	case "Nama":
+		return LanguageNama, true
+	case "NauruLanguage":
+		return LanguageNauruLanguage, true
+	case "Navaho":
+		return LanguageNavaho, true
+	case "Ndonga":
+		return LanguageNdonga, true
+	case "Nepali":
+		return LanguageNepali, true
+	case "Newari":
+		return LanguageNewari, true
+	case "Ngiemboon":
+		return LanguageNgiemboon, true
+	case "Ngomba":
+		return LanguageNgomba, true
+	case "Nko":
+		return LanguageNko, true
+	case "NorthNdebele":
+		return LanguageNorthNdebele, true
+	case "NorthernLuri":
+		return LanguageNorthernLuri, true
+	case "NorthernSami":
+		return LanguageNorthernSami, true
+	case "NorthernSotho":
+		return LanguageNorthernSotho, true
+	case "NorthernThai":
+		return LanguageNorthernThai, true
+	case "Norwegian":
+		return LanguageNorwegian, true
+	case "NorwegianBokmal":
+		return LanguageNorwegianBokmal, true
+	case "NorwegianNynorsk":
+		return LanguageNorwegianNynorsk, true
+	case "Nuer":
+		return LanguageNuer, true
+	case "Nyanja":
+		return LanguageNyanja, true
+	case "Nyankole":
+		return LanguageNyankole, true
+	case "Occitan":
+		return LanguageOccitan, true
+	case "Ojibwa":
+		return LanguageOjibwa, true
+	case "OldIrish":
+		return LanguageOldIrish, true
+	case "OldNorse":
+		return LanguageOldNorse, true
+	case "OldPersian":
+		return LanguageOldPersian, true
+	case "OldTurkish":
+		return LanguageOldTurkish, true
+	case "Oriya":
+		return LanguageOriya, true
+	case "Oromo":
+		return LanguageOromo, true
+	case "Osage":
+		return LanguageOsage, true
+	case "Ossetic":
+		return LanguageOssetic, true
+	case "Pahlavi":
+		return LanguagePahlavi, true
+	case "Palauan":
+		return LanguagePalauan, true
+	case "Pali":
+		return LanguagePali, true
+	case "Papiamento":
+		return LanguagePapiamento, true
+	case "Parthian":
+		return LanguageParthian, true
+	case "Pashto":
+		return LanguagePashto, true
+	case "Persian":
+		return LanguagePersian, true
+	case "Phoenician":
+		return LanguagePhoenician, true
+	case "Polish":
+		return LanguagePolish, true
+	case "Portuguese":
+		return LanguagePortuguese, true
+	case "PrakritLanguage":
+		return LanguagePrakritLanguage, true
+	case "Prussian":
+		return LanguagePrussian, true
+	case "Punjabi":
+		return LanguagePunjabi, true
+	case "Quechua":
+		return LanguageQuechua, true
+	case "Rejang":
+		return LanguageRejang, true
+	case "RhaetoRomance":
+		return LanguageRhaetoRomance, true
+	case "Romanian":
+		return LanguageRomanian, true
+	case "Romansh":
+		return LanguageRomansh, true
+	case "Rombo":
+		return LanguageRombo, true
+	case "Rundi":
+		return LanguageRundi, true
+	case "Russian":
+		return LanguageRussian, true
+	case "Rwa":
+		return LanguageRwa, true
+	case "Sabaean":
+		return LanguageSabaean, true
+	case "Saho":
+		return LanguageSaho, true
+	case "Sakha":
+		return LanguageSakha, true
+	case "Samaritan":
+		return LanguageSamaritan, true
+	case "Samburu":
+		return LanguageSamburu, true
+	case "Samoan":
+		return LanguageSamoan, true
+	case "Sango":
+		return LanguageSango, true
+	case "Sangu":
+		return LanguageSangu, true
+	case "Sanskrit":
+		return LanguageSanskrit, true
+	case "Santali":
+		return LanguageSantali, true
+	case "Saraiki":
+		return LanguageSaraiki, true
+	case "Sardinian":
+		return LanguageSardinian, true
+	case "Saurashtra":
+		return LanguageSaurashtra, true
+	case "Sena":
+		return LanguageSena, true
+	case "Serbian":
+		return LanguageSerbian, true
+	case "SerboCroatian":
+		return LanguageSerboCroatian, true
+	case "Shambala":
+		return LanguageShambala, true
+	case "Shona":
+		return LanguageShona, true
+	case "SichuanYi":
+		return LanguageSichuanYi, true
+	case "Sicilian":
+		return LanguageSicilian, true
+	case "Sidamo":
+		return LanguageSidamo, true
+	case "Sindhi":
+		return LanguageSindhi, true
+	case "Sinhala":
+		return LanguageSinhala, true
+	case "SkoltSami":
+		return LanguageSkoltSami, true
+	case "Slovak":
+		return LanguageSlovak, true
+	case "Slovenian":
+		return LanguageSlovenian, true
+	case "Soga":
+		return LanguageSoga, true
+	case "Somali":
+		return LanguageSomali, true
+	case "Sora":
+		return LanguageSora, true
+	case "SouthNdebele":
+		return LanguageSouthNdebele, true
+	case "SouthernKurdish":
+		return LanguageSouthernKurdish, true
+	case "SouthernSami":
+		return LanguageSouthernSami, true
+	case "SouthernSotho":
+		return LanguageSouthernSotho, true
+	case "Spanish":
+		return LanguageSpanish, true
+	case "StandardMoroccanTamazight":
+		return LanguageStandardMoroccanTamazight, true
+	case "Sundanese":
+		return LanguageSundanese, true
+	case "Swahili":
+		return LanguageSwahili, true
+	case "Swati":
+		return LanguageSwati, true
+	case "Swedish":
+		return LanguageSwedish, true
+	case "SwissGerman":
+		return LanguageSwissGerman, true
+	case "Sylheti":
+		return LanguageSylheti, true
+	case "Syriac":
+		return LanguageSyriac, true
+	case "Tachelhit":
+		return LanguageTachelhit, true
+	case "Tagalog":
+		return LanguageTagalog, true
+	case "Tagbanwa":
+		return LanguageTagbanwa, true
+	case "Tahitian":
+		return LanguageTahitian, true
+	case "TaiDam":
+		return LanguageTaiDam, true
+	case "TaiNua":
+		return LanguageTaiNua, true
+	case "Taita":
+		return LanguageTaita, true
+	case "Tajik":
+		return LanguageTajik, true
+	case "Tamil":
+		return LanguageTamil, true
+	case "Tangut":
+		return LanguageTangut, true
+	case "Taroko":
+		return LanguageTaroko, true
+	case "Tasawaq":
+		return LanguageTasawaq, true
+	case "Tatar":
+		return LanguageTatar, true
+	case "TedimChin":
+		return LanguageTedimChin, true
+	case "Telugu":
+		return LanguageTelugu, true
+	case "Teso":
+		return LanguageTeso, true
+	case "Thai":
+		return LanguageThai, true
+	case "Tibetan":
+		return LanguageTibetan, true
+	case "Tigre":
+		return LanguageTigre, true
+	case "Tigrinya":
+		return LanguageTigrinya, true
+	case "TokPisin":
+		return LanguageTokPisin, true
+	case "TokelauLanguage":
+		return LanguageTokelauLanguage, true
+	case "Tongan":
+		return LanguageTongan, true
+	case "Tsonga":
+		return LanguageTsonga, true
+	case "Tswana":
+		return LanguageTswana, true
+	case "Turkish":
+		return LanguageTurkish, true
+	case "Turkmen":
+		return LanguageTurkmen, true
+	case "TuvaluLanguage":
+		return LanguageTuvaluLanguage, true
+	case "Twi":
+		return LanguageTwi, true
+	case "Tyap":
+		return LanguageTyap, true
+	case "Ugaritic":
+		return LanguageUgaritic, true
+	case "Uighur":
+		return LanguageUighur, true
+	case "Uigur":
+		return LanguageUigur, true
+	case "Ukrainian":
+		return LanguageUkrainian, true
+	case "UncodedLanguages":
+		return LanguageUncodedLanguages, true
+	case "UpperSorbian":
+		return LanguageUpperSorbian, true
+	case "Urdu":
+		return LanguageUrdu, true
+	case "Uzbek":
+		return LanguageUzbek, true
+	case "Vai":
+		return LanguageVai, true
+	case "Venda":
+		return LanguageVenda, true
+	case "Vietnamese":
+		return LanguageVietnamese, true
+	case "Volapuk":
+		return LanguageVolapuk, true
+	case "Vunjo":
+		return LanguageVunjo, true
+	case "Walamo":
+		return LanguageWalamo, true
+	case "Walloon":
+		return LanguageWalloon, true
+	case "Walser":
+		return LanguageWalser, true
+	case "Warlpiri":
+		return LanguageWarlpiri, true
+	case "Welsh":
+		return LanguageWelsh, true
+	case "WesternBalochi":
+		return LanguageWesternBalochi, true
+	case "WesternFrisian":
+		return LanguageWesternFrisian, true
+	case "Wolof":
+		return LanguageWolof, true
+	case "Xhosa":
+		return LanguageXhosa, true
+	case "Yangben":
+		return LanguageYangben, true
+	case "Yiddish":
+		return LanguageYiddish, true
+	case "Yoruba":
+		return LanguageYoruba, true
+	case "Zarma":
+		return LanguageZarma, true
+	case "Zhuang":
+		return LanguageZhuang, true
+	case "Zulu":
+		return LanguageZulu, true
+	}
+	return 0, false
+}
+
 // Country is a country supported by Qt (note: multiple names can have the same
 // code).
 type Country uint16
@@ -1932,3 +2700,555 @@ func (c Country) String() string {
 	}
 	panic("no such country")
 }
+
+// ParseCountry returns the Country with the provided name (which may be an
+// alias). If there isn't one, false is returned.
+func ParseCountry(s string) (Country, bool) {
+	switch s {
+	case "Afghanistan":
+		return CountryAfghanistan, true
+	case "AlandIslands":
+		return CountryAlandIslands, true
+	case "Albania":
+		return CountryAlbania, true
+	case "Algeria":
+		return CountryAlgeria, true
+	case "AmericanSamoa":
+		return CountryAmericanSamoa, true
+	case "Andorra":
+		return CountryAndorra, true
+	case "Angola":
+		return CountryAngola, true
+	case "Anguilla":
+		return CountryAnguilla, true
+	case "Antarctica":
+		return CountryAntarctica, true
+	case "AntiguaAndBarbuda":
+		return CountryAntiguaAndBarbuda, true
+	case "AnyCountry":
+		return CountryAnyCountry, true
+	case "Argentina":
+		return CountryArgentina, true
+	case "Armenia":
+		return CountryArmenia, true
+	case "Aruba":
+		return CountryAruba, true
+	case "AscensionIsland":
+		return CountryAscensionIsland, true
+	case "Australia":
+		return CountryAustralia, true
+	case "Austria":
+		return CountryAustria, true
+	case "Azerbaijan":
+		return CountryAzerbaijan, true
+	case "Bahamas":
+		return CountryBahamas, true
+	case "Bahrain":
+		return CountryBahrain, true
+	case "Bangladesh":
+		return CountryBangladesh, true
+	case "Barbados":
+		return CountryBarbados, true
+	case "Belarus":
+		return CountryBelarus, true
+	case "Belgium":
+		return CountryBelgium, true
+	case "Belize":
+		return CountryBelize, true
+	case "Benin":
+		return CountryBenin, true
+	case "Bermuda":
+		return CountryBermuda, true
+	case "Bhutan":
+		return CountryBhutan, true
+	case "Bolivia":
+		return CountryBolivia, true
+	case "Bonaire":
+		return CountryBonaire, true
+	case "BosniaAndHerzegowina":
+		return CountryBosniaAndHerzegowina, true
+	case "Botswana":
+		return CountryBotswana, true
+	case "BouvetIsland":
+		return CountryBouvetIsland, true
+	case "Brazil":
+		return CountryBrazil, true
+	case "BritishIndianOceanTerritory":
+		return CountryBritishIndianOceanTerritory, true
+	case "BritishVirginIslands":
+		return CountryBritishVirginIslands, true
+	case "Brunei":
+		return CountryBrunei, true
+	case "Bulgaria":
+		return CountryBulgaria, true
+	case "BurkinaFaso":
+		return CountryBurkinaFaso, true
+	case "Burundi":
+		return CountryBurundi, true
+	case "Cambodia":
+		return CountryCambodia, true
+	case "Cameroon":
+		return CountryCameroon, true
+	case "Canada":
+		return CountryCanada, true
+	case "CanaryIslands":
+		return CountryCanaryIslands, true
+	case "CapeVerde":
+		return CountryCapeVerde, true
+	case "CaymanIslands":
+		return CountryCaymanIslands, true
+	case "CentralAfricanRepublic":
+		return CountryCentralAfricanRepublic, true
+	case "CeutaAndMelilla":
+		return CountryCeutaAndMelilla, true
+	case "Chad":
+		return CountryChad, true
+	case "Chile":
+		return CountryChile, true
+	case "China":
+		return CountryChina, true
+	case "ChristmasIsland":
+		return CountryChristmasIsland, true
+	case "ClippertonIsland":
+		return CountryClippertonIsland, true
+	case "CocosIslands":
+		return CountryCocosIslands, true
+	case "Colombia":
+		return CountryColombia, true
+	case "Comoros":
+		return CountryComoros, true
+	case "CongoBrazzaville":
+		return CountryCongoBrazzaville, true
+	case "CongoKinshasa":
+		return CountryCongoKinshasa, true
+	case "CookIslands":
+		return CountryCookIslands, true
+	case "CostaRica":
+		return CountryCostaRica, true
+	case "Croatia":
+		return CountryCroatia, true
+	case "Cuba":
+		return CountryCuba, true
+	case "CuraSao":
+		return CountryCuraSao, true
+	case "Cyprus":
+		return CountryCyprus, true
+	case "CzechRepublic":
+		return CountryCzechRepublic, true
+	case "DemocraticRepublicOfCongo":
+		return CountryDemocraticRepublicOfCongo, true
+	case "DemocraticRepublicOfKorea":
+		return CountryDemocraticRepublicOfKorea, true
+	case "Denmark":
+		return CountryDenmark, true
+	case "DiegoGarcia":
+		return CountryDiegoGarcia, true
+	case "Djibouti":
+		return CountryDjibouti, true
+	case "Dominica":
+		return CountryDominica, true
+	case "DominicanRepublic":
+		return CountryDominicanRepublic, true
+	case "EastTimor":
+		return CountryEastTimor, true
+	case "Ecuador":
+		return CountryEcuador, true
+	case "Egypt":
+		return CountryEgypt, true
+	case "ElSalvador":
+		return CountryElSalvador, true
+	case "EquatorialGuinea":
+		return CountryEquatorialGuinea, true
+	case "Eritrea":
+		return CountryEritrea, true
+	case "Estonia":
+		return CountryEstonia, true
+	case "Ethiopia":
+		return CountryEthiopia, true
+	case "Europe":
+		return CountryEurope, true
+	case "EuropeanUnion":
+		return CountryEuropeanUnion, true
+	case "FalklandIslands":
+		return CountryFalklandIslands, true
+	case "FaroeIslands":
+		return CountryFaroeIslands, true
+	case "Fiji":
+		return CountryFiji, true
+	case "Finland":
+		return CountryFinland, true
+	case "France":
+		return CountryFrance, true
+	case "FrenchGuiana":
+		return CountryFrenchGuiana, true
+	case "FrenchPolynesia":
+		return CountryFrenchPolynesia, true
+	case "FrenchSouthernTerritories":
+		return CountryFrenchSouthernTerritories, true
+	case "Gabon":
+		return CountryGabon, true
+	case "Gambia":
+		return CountryGambia, true
+	case "Georgia":
+		return CountryGeorgia, true
+	case "Germany":
+		return CountryGermany, true
+	case "Ghana":
+		return CountryGhana, true
+	case "Gibraltar":
+		return CountryGibraltar, true
+	case "Greece":
+		return CountryGreece, true
+	case "Greenland":
+		return CountryGreenland, true
+	case "Grenada":
+		return CountryGrenada, true
+	case "Guadeloupe":
+		return CountryGuadeloupe, true
+	case "Guam":
+		return CountryGuam, true
+	case "Guatemala":
+		return CountryGuatemala, true
+	case "Guernsey":
+		return CountryGuernsey, true
+	case "Guinea":
+		return CountryGuinea, true
+	case "GuineaBissau":
+		return CountryGuineaBissau, true
+	case "Guyana":
+		return CountryGuyana, true
+	case "Haiti":
+		return CountryHaiti, true
+	case "HeardAndMcDonaldIslands":
+		return CountryHeardAndMcDonaldIslands, true
+	case "Honduras":
+		return CountryHonduras, true
+	case "HongKong":
+		return CountryHongKong, true
+	case "Hungary":
+		return CountryHungary, true
+	case "Iceland":
+		return CountryIceland, true
+	case "India":
+		return CountryIndia, true
+	case "Indonesia":
+		return CountryIndonesia, true
+	case "Iran":
+		return CountryIran, true
+	case "Iraq":
+		return CountryIraq, true
+	case "Ireland":
+		return CountryIreland, true
+	case "IsleOfMan":
+		return CountryIsleOfMan, true
+	case "Israel":
+		return CountryIsrael, true
+	case "Italy":
+		return CountryItaly, true
+	case "IvoryCoast":
+		return CountryIvoryCoast, true
+	case "Jamaica":
+		return CountryJamaica, true
+	case "Japan":
+		return CountryJapan, true
+	case "Jersey":
+		return CountryJersey, true
+	case "Jordan":
+		return CountryJordan, true
+	case "Kazakhstan":
+		return CountryKazakhstan, true
+	case "Kenya":
+		return CountryKenya, true
+	case "Kiribati":
+		return CountryKiribati, true
+	case "Kosovo":
+		return CountryKosovo, true
+	case "Kuwait":
+		return CountryKuwait, true
+	case "Kyrgyzstan":
+		return CountryKyrgyzstan, true
+	case "Laos":
+		return CountryLaos, true
+	case "LastCountry":
+		return CountryLastCountry, true
+	case "LatinAmerica":
+		return CountryLatinAmerica, true
+	case "LatinAmericaAndTheCaribbean":
+		return CountryLatinAmericaAndTheCaribbean, true
+	case "Latvia":
+		return CountryLatvia, true
+	case "Lebanon":
+		return CountryLebanon, true
+	case "Lesotho":
+		return CountryLesotho, true
+	case "Liberia":
+		return CountryLiberia, true
+	case "Libya":
+		return CountryLibya, true
+	case "Liechtenstein":
+		return CountryLiechtenstein, true
+	case "Lithuania":
+		return CountryLithuania, true
+	case "Luxembourg":
+		return CountryLuxembourg, true
+	case "Macau":
+		return CountryMacau, true
+	case "Macedonia":
+		return CountryMacedonia, true
+	case "Madagascar":
+		return CountryMadagascar, true
+	case "Malawi":
+		return CountryMalawi, true
+	case "Malaysia":
+		return CountryMalaysia, true
+	case "Maldives":
+		return CountryMaldives, true
+	case "Mali":
+		return CountryMali, true
+	case "Malta":
+		return CountryMalta, true
+	case "MarshallIslands":
+		return CountryMarshallIslands, true
+	case "Martinique":
+		return CountryMartinique, true
+	case "Mauritania":
+		return CountryMauritania, true
+	case "Mauritius":
+		return CountryMauritius, true
+	case "Mayotte":
+		return CountryMayotte, true
+	case "Mexico":
+		return CountryMexico, true
+	case "Micronesia":
+		return CountryMicronesia, true
+	case "Moldova":
+		return CountryMoldova, true
+	case "Monaco":
+		return CountryMonaco, true
+	case "Mongolia":
+		return CountryMongolia, true
+	case "Montenegro":
+		return CountryMontenegro, true
+	case "Montserrat":
+		return CountryMontserrat, true
+	case "Morocco":
+		return CountryMorocco, true
+	case "Mozambique":
+		return CountryMozambique, true
+	case "Myanmar":
+		return CountryMyanmar, true
+	case "Namibia":
+		return CountryNamibia, true
+	case "NauruCountry":
+		return CountryNauruCountry, true
+	case "Nepal":
+		return CountryNepal, true
+	case "Netherlands":
+		return CountryNetherlands, true
+	case "NewCaledonia":
+		return CountryNewCaledonia, true
+	case "NewZealand":
+		return CountryNewZealand, true
+	case "Nicaragua":
+		return CountryNicaragua, true
+	case "Niger":
+		return CountryNiger, true
+	case "Nigeria":
+		return CountryNigeria, true
+	case "Niue":
+		return CountryNiue, true
+	case "NorfolkIsland":
+		return CountryNorfolkIsland, true
+	case "NorthKorea":
+		return CountryNorthKorea, true
+	case "NorthernMarianaIslands":
+		return CountryNorthernMarianaIslands, true
+	case "Norway":
+		return CountryNorway, true
+	case "Oman":
+		return CountryOman, true
+	case "OutlyingOceania":
+		return CountryOutlyingOceania, true
+	case "Pakistan":
+		return CountryPakistan, true
+	case "Palau":
+		return CountryPalau, true
+	case "PalestinianTerritories":
+		return CountryPalestinianTerritories, true
+	case "Panama":
+		return CountryPanama, true
+	case "PapuaNewGuinea":
+		return CountryPapuaNewGuinea, true
+	case "Paraguay":
+		return CountryParaguay, true
+	case "PeoplesRepublicOfCongo":
+		return CountryPeoplesRepublicOfCongo, true
+	case "Peru":
+		return CountryPeru, true
+	case "Philippines":
+		return CountryPhilippines, true
+	case "Pitcairn":
+		return CountryPitcairn, true
+	case "Poland":
+		return CountryPoland, true
+	case "Portugal":
+		return CountryPortugal, true
+	case "PuertoRico":
+		return CountryPuertoRico, true
+	case "Qatar":
+		return CountryQatar, true
+	case "RepublicOfKorea":
+		return CountryRepublicOfKorea, true
+	case "Reunion":
+		return CountryReunion, true
+	case "Romania":
+		return CountryRomania, true
+	case "Russia":
+		return CountryRussia, true
+	case "RussianFederation":
+		return CountryRussianFederation, true
+	case "Rwanda":
+		return CountryRwanda, true
+	case "SaintBarthelemy":
+		return CountrySaintBarthelemy, true
+	case "SaintHelena":
+		return CountrySaintHelena, true
+	case "SaintKittsAndNevis":
+		return CountrySaintKittsAndNevis, true
+	case "SaintLucia":
+		return CountrySaintLucia, true
+	case "SaintMartin":
+		return CountrySaintMartin, true
+	case "SaintPierreAndMiquelon":
+		return CountrySaintPierreAndMiquelon, true
+	case "SaintVincentAndTheGrenadines":
+		return CountrySaintVincentAndTheGrenadines, true
+	case "Samoa":
+		return CountrySamoa, true
+	case "SanMarino":
+		return CountrySanMarino, true
+	case "SaoTomeAndPrincipe":
+		return CountrySaoTomeAndPrincipe, true
+	case "SaudiArabia":
+		return CountrySaudiArabia, true
+	case "Senegal":
+		return CountrySenegal, true
+	case "Serbia":
+		return CountrySerbia, true
+	case "Seychelles":
+		return CountrySeychelles, true
+	case "SierraLeone":
+		return CountrySierraLeone, true
+	case "Singapore":
+		return CountrySingapore, true
+	case "SintMaarten":
+		return CountrySintMaarten, true
+	case "Slovakia":
+		return CountrySlovakia, true
+	case "Slovenia":
+		return CountrySlovenia, true
+	case "SolomonIslands":
+		return CountrySolomonIslands, true
+	case "Somalia":
+		return CountrySomalia, true
+	case "SouthAfrica":
+		return CountrySouthAfrica, true
+	case "SouthGeorgiaAndTheSouthSandwichIslands":
+		return CountrySouthGeorgiaAndTheSouthSandwichIslands, true
+	case "SouthKorea":
+		return CountrySouthKorea, true
+	case "SouthSudan":
+		return CountrySouthSudan, true
+	case "Spain":
+		return CountrySpain, true
+	case "SriLanka":
+		return CountrySriLanka, true
+	case "Sudan":
+		return CountrySudan, true
+	case "Suriname":
+		return CountrySuriname, true
+	case "SvalbardAndJanMayenIslands":
+		return CountrySvalbardAndJanMayenIslands, true
+	case "Swaziland":
+		return CountrySwaziland, true
+	case "Sweden":
+		return CountrySweden, true
+	case "Switzerland":
+		return CountrySwitzerland, true
+	case "Syria":
+		return CountrySyria, true
+	case "SyrianArabRepublic":
+		return CountrySyrianArabRepublic, true
+	case "Taiwan":
+		return CountryTaiwan, true
+	case "Tajikistan":
+		return CountryTajikistan, true
+	case "Tanzania":
+		return CountryTanzania, true
+	case "Thailand":
+		return CountryThailand, true
+	case "Togo":
+		return CountryTogo, true
+	case "Tokelau":
+		return CountryTokelau, true
+	case "TokelauCountry":
+		return CountryTokelauCountry, true
+	case "Tonga":
+		return CountryTonga, true
+	case "TrinidadAndTobago":
+		return CountryTrinidadAndTobago, true
+	case "TristanDaCunha":
+		return CountryTristanDaCunha, true
+	case "Tunisia":
+		return CountryTunisia, true
+	case "Turkey":
+		return CountryTurkey, true
+	case "Turkmenistan":
+		return CountryTurkmenistan, true
+	case "TurksAndCaicosIslands":
+		return CountryTurksAndCaicosIslands, true
+	case "Tuvalu":
+		return CountryTuvalu, true
+	case "TuvaluCountry":
+		return CountryTuvaluCountry, true
+	case "Uganda":
+		return CountryUganda, true
+	case "Ukraine":
+		return CountryUkraine, true
+	case "UnitedArabEmirates":
+		return CountryUnitedArabEmirates, true
+	case "UnitedKingdom":
+		return CountryUnitedKingdom, true
+	case "UnitedStates":
+		return CountryUnitedStates, true
+	case "UnitedStatesMinorOutlyingIslands":
+		return CountryUnitedStatesMinorOutlyingIslands, true
+	case "UnitedStatesVirginIslands":
+		return CountryUnitedStatesVirginIslands, true
+	case "Uruguay":
+		return CountryUruguay, true
+	case "Uzbekistan":
+		return CountryUzbekistan, true
+	case "Vanuatu":
+		return CountryVanuatu, true
+	case "VaticanCityState":
+		return CountryVaticanCityState, true
+	case "Venezuela":
+		return CountryVenezuela, true
+	case "Vietnam":
+		return CountryVietnam, true
+	case "WallisAndFutunaIslands":
+		return CountryWallisAndFutunaIslands, true
+	case "WesternSahara":
+		return CountryWesternSahara, true
+	case "World":
+		return CountryWorld, true
+	case "Yemen":
+		return CountryYemen, true
+	case "Zambia":
+		return CountryZambia, true
+	case "Zimbabwe":
+		return CountryZimbabwe, true
+	}
+	return 0, false
+}
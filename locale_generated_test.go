@@ -1964,3 +1964,1971 @@ func TestCountry(t *testing.T) {
 		t.Errorf("%q incorrect", "LastCountry")
 	}
 }
+
+func TestParseLanguage(t *testing.T) {
+	if v, ok := ParseLanguage("AnyLanguage"); !ok || v != LanguageAnyLanguage {
+		t.Errorf("%q incorrect", "AnyLanguage")
+	}
+	if v, ok := ParseLanguage("C"); !ok || v != LanguageC {
+		t.Errorf("%q incorrect", "C")
+	}
+	if v, ok := ParseLanguage("Abkhazian"); !ok || v != LanguageAbkhazian {
+		t.Errorf("%q incorrect", "Abkhazian")
+	}
+	if v, ok := ParseLanguage("Afan"); !ok || v != LanguageAfan {
+		t.Errorf("%q incorrect", "Afan")
+	}
+	if v, ok := ParseLanguage("Oromo"); !ok || v != LanguageOromo {
+		t.Errorf("%q incorrect", "Oromo")
+	}
+	if v, ok := ParseLanguage("Afar"); !ok || v != LanguageAfar {
+		t.Errorf("%q incorrect", "Afar")
+	}
+	if v, ok := ParseLanguage("Afrikaans"); !ok || v != LanguageAfrikaans {
+		t.Errorf("%q incorrect", "Afrikaans")
+	}
+	if v, ok := ParseLanguage("Albanian"); !ok || v != LanguageAlbanian {
+		t.Errorf("%q incorrect", "Albanian")
+	}
+	if v, ok := ParseLanguage("Amharic"); !ok || v != LanguageAmharic {
+		t.Errorf("%q incorrect", "Amharic")
+	}
+	if v, ok := ParseLanguage("Arabic"); !ok || v != LanguageArabic {
+		t.Errorf("%q incorrect", "Arabic")
+	}
+	if v, ok := ParseLanguage("Armenian"); !ok || v != LanguageArmenian {
+		t.Errorf("%q incorrect", "Armenian")
+	}
+	if v, ok := ParseLanguage("Assamese"); !ok || v != LanguageAssamese {
+		t.Errorf("%q incorrect", "Assamese")
+	}
+	if v, ok := ParseLanguage("Aymara"); !ok || v != LanguageAymara {
+		t.Errorf("%q incorrect", "Aymara")
+	}
+	if v, ok := ParseLanguage("Azerbaijani"); !ok || v != LanguageAzerbaijani {
+		t.Errorf("%q incorrect", "Azerbaijani")
+	}
+	if v, ok := ParseLanguage("Bashkir"); !ok || v != LanguageBashkir {
+		t.Errorf("%q incorrect", "Bashkir")
+	}
+	if v, ok := ParseLanguage("Basque"); !ok || v != LanguageBasque {
+		t.Errorf("%q incorrect", "Basque")
+	}
+	if v, ok := ParseLanguage("Bengali"); !ok || v != LanguageBengali {
+		t.Errorf("%q incorrect", "Bengali")
+	}
+	if v, ok := ParseLanguage("Bhutani"); !ok || v != LanguageBhutani {
+		t.Errorf("%q incorrect", "Bhutani")
+	}
+	if v, ok := ParseLanguage("Dzongkha"); !ok || v != LanguageDzongkha {
+		t.Errorf("%q incorrect", "Dzongkha")
+	}
+	if v, ok := ParseLanguage("Bihari"); !ok || v != LanguageBihari {
+		t.Errorf("%q incorrect", "Bihari")
+	}
+	if v, ok := ParseLanguage("Bislama"); !ok || v != LanguageBislama {
+		t.Errorf("%q incorrect", "Bislama")
+	}
+	if v, ok := ParseLanguage("Breton"); !ok || v != LanguageBreton {
+		t.Errorf("%q incorrect", "Breton")
+	}
+	if v, ok := ParseLanguage("Bulgarian"); !ok || v != LanguageBulgarian {
+		t.Errorf("%q incorrect", "Bulgarian")
+	}
+	if v, ok := ParseLanguage("Burmese"); !ok || v != LanguageBurmese {
+		t.Errorf("%q incorrect", "Burmese")
+	}
+	if v, ok := ParseLanguage("Byelorussian"); !ok || v != LanguageByelorussian {
+		t.Errorf("%q incorrect", "Byelorussian")
+	}
+	if v, ok := ParseLanguage("Belarusian"); !ok || v != LanguageBelarusian {
+		t.Errorf("%q incorrect", "Belarusian")
+	}
+	if v, ok := ParseLanguage("Khmer"); !ok || v != LanguageKhmer {
+		t.Errorf("%q incorrect", "Khmer")
+	}
+	if v, ok := ParseLanguage("Cambodian"); !ok || v != LanguageCambodian {
+		t.Errorf("%q incorrect", "Cambodian")
+	}
+	if v, ok := ParseLanguage("Catalan"); !ok || v != LanguageCatalan {
+		t.Errorf("%q incorrect", "Catalan")
+	}
+	if v, ok := ParseLanguage("Chinese"); !ok || v != LanguageChinese {
+		t.Errorf("%q incorrect", "Chinese")
+	}
+	if v, ok := ParseLanguage("Corsican"); !ok || v != LanguageCorsican {
+		t.Errorf("%q incorrect", "Corsican")
+	}
+	if v, ok := ParseLanguage("Croatian"); !ok || v != LanguageCroatian {
+		t.Errorf("%q incorrect", "Croatian")
+	}
+	if v, ok := ParseLanguage("Czech"); !ok || v != LanguageCzech {
+		t.Errorf("%q incorrect", "Czech")
+	}
+	if v, ok := ParseLanguage("Danish"); !ok || v != LanguageDanish {
+		t.Errorf("%q incorrect", "Danish")
+	}
+	if v, ok := ParseLanguage("Dutch"); !ok || v != LanguageDutch {
+		t.Errorf("%q incorrect", "Dutch")
+	}
+	if v, ok := ParseLanguage("English"); !ok || v != LanguageEnglish {
+		t.Errorf("%q incorrect", "English")
+	}
+	if v, ok := ParseLanguage("Esperanto"); !ok || v != LanguageEsperanto {
+		t.Errorf("%q incorrect", "Esperanto")
+	}
+	if v, ok := ParseLanguage("Estonian"); !ok || v != LanguageEstonian {
+		t.Errorf("%q incorrect", "Estonian")
+	}
+	if v, ok := ParseLanguage("Faroese"); !ok || v != LanguageFaroese {
+		t.Errorf("%q incorrect", "Faroese")
+	}
+	if v, ok := ParseLanguage("Fijian"); !ok || v != LanguageFijian {
+		t.Errorf("%q incorrect", "Fijian")
+	}
+	if v, ok := ParseLanguage("Finnish"); !ok || v != LanguageFinnish {
+		t.Errorf("%q incorrect", "Finnish")
+	}
+	if v, ok := ParseLanguage("French"); !ok || v != LanguageFrench {
+		t.Errorf("%q incorrect", "French")
+	}
+	if v, ok := ParseLanguage("WesternFrisian"); !ok || v != LanguageWesternFrisian {
+		t.Errorf("%q incorrect", "WesternFrisian")
+	}
+	if v, ok := ParseLanguage("Frisian"); !ok || v != LanguageFrisian {
+		t.Errorf("%q incorrect", "Frisian")
+	}
+	if v, ok := ParseLanguage("Gaelic"); !ok || v != LanguageGaelic {
+		t.Errorf("%q incorrect", "Gaelic")
+	}
+	if v, ok := ParseLanguage("Galician"); !ok || v != LanguageGalician {
+		t.Errorf("%q incorrect", "Galician")
+	}
+	if v, ok := ParseLanguage("Georgian"); !ok || v != LanguageGeorgian {
+		t.Errorf("%q incorrect", "Georgian")
+	}
+	if v, ok := ParseLanguage("German"); !ok || v != LanguageGerman {
+		t.Errorf("%q incorrect", "German")
+	}
+	if v, ok := ParseLanguage("Greek"); !ok || v != LanguageGreek {
+		t.Errorf("%q incorrect", "Greek")
+	}
+	if v, ok := ParseLanguage("Greenlandic"); !ok || v != LanguageGreenlandic {
+		t.Errorf("%q incorrect", "Greenlandic")
+	}
+	if v, ok := ParseLanguage("Guarani"); !ok || v != LanguageGuarani {
+		t.Errorf("%q incorrect", "Guarani")
+	}
+	if v, ok := ParseLanguage("Gujarati"); !ok || v != LanguageGujarati {
+		t.Errorf("%q incorrect", "Gujarati")
+	}
+	if v, ok := ParseLanguage("Hausa"); !ok || v != LanguageHausa {
+		t.Errorf("%q incorrect", "Hausa")
+	}
+	if v, ok := ParseLanguage("Hebrew"); !ok || v != LanguageHebrew {
+		t.Errorf("%q incorrect", "Hebrew")
+	}
+	if v, ok := ParseLanguage("Hindi"); !ok || v != LanguageHindi {
+		t.Errorf("%q incorrect", "Hindi")
+	}
+	if v, ok := ParseLanguage("Hungarian"); !ok || v != LanguageHungarian {
+		t.Errorf("%q incorrect", "Hungarian")
+	}
+	if v, ok := ParseLanguage("Icelandic"); !ok || v != LanguageIcelandic {
+		t.Errorf("%q incorrect", "Icelandic")
+	}
+	if v, ok := ParseLanguage("Indonesian"); !ok || v != LanguageIndonesian {
+		t.Errorf("%q incorrect", "Indonesian")
+	}
+	if v, ok := ParseLanguage("Interlingua"); !ok || v != LanguageInterlingua {
+		t.Errorf("%q incorrect", "Interlingua")
+	}
+	if v, ok := ParseLanguage("Interlingue"); !ok || v != LanguageInterlingue {
+		t.Errorf("%q incorrect", "Interlingue")
+	}
+	if v, ok := ParseLanguage("Inuktitut"); !ok || v != LanguageInuktitut {
+		t.Errorf("%q incorrect", "Inuktitut")
+	}
+	if v, ok := ParseLanguage("Inupiak"); !ok || v != LanguageInupiak {
+		t.Errorf("%q incorrect", "Inupiak")
+	}
+	if v, ok := ParseLanguage("Irish"); !ok || v != LanguageIrish {
+		t.Errorf("%q incorrect", "Irish")
+	}
+	if v, ok := ParseLanguage("Italian"); !ok || v != LanguageItalian {
+		t.Errorf("%q incorrect", "Italian")
+	}
+	if v, ok := ParseLanguage("Japanese"); !ok || v != LanguageJapanese {
+		t.Errorf("%q incorrect", "Japanese")
+	}
+	if v, ok := ParseLanguage("Javanese"); !ok || v != LanguageJavanese {
+		t.Errorf("%q incorrect", "Javanese")
+	}
+	if v, ok := ParseLanguage("Kannada"); !ok || v != LanguageKannada {
+		t.Errorf("%q incorrect", "Kannada")
+	}
+	if v, ok := ParseLanguage("Kashmiri"); !ok || v != LanguageKashmiri {
+		t.Errorf("%q incorrect", "Kashmiri")
+	}
+	if v, ok := ParseLanguage("Kazakh"); !ok || v != LanguageKazakh {
+		t.Errorf("%q incorrect", "Kazakh")
+	}
+	if v, ok := ParseLanguage("Kinyarwanda"); !ok || v != LanguageKinyarwanda {
+		t.Errorf("%q incorrect", "Kinyarwanda")
+	}
+	if v, ok := ParseLanguage("Kirghiz"); !ok || v != LanguageKirghiz {
+		t.Errorf("%q incorrect", "Kirghiz")
+	}
+	if v, ok := ParseLanguage("Korean"); !ok || v != LanguageKorean {
+		t.Errorf("%q incorrect", "Korean")
+	}
+	if v, ok := ParseLanguage("Kurdish"); !ok || v != LanguageKurdish {
+		t.Errorf("%q incorrect", "Kurdish")
+	}
+	if v, ok := ParseLanguage("Rundi"); !ok || v != LanguageRundi {
+		t.Errorf("%q incorrect", "Rundi")
+	}
+	if v, ok := ParseLanguage("Kurundi"); !ok || v != LanguageKurundi {
+		t.Errorf("%q incorrect", "Kurundi")
+	}
+	if v, ok := ParseLanguage("Lao"); !ok || v != LanguageLao {
+		t.Errorf("%q incorrect", "Lao")
+	}
+	if v, ok := ParseLanguage("Latin"); !ok || v != LanguageLatin {
+		t.Errorf("%q incorrect", "Latin")
+	}
+	if v, ok := ParseLanguage("Latvian"); !ok || v != LanguageLatvian {
+		t.Errorf("%q incorrect", "Latvian")
+	}
+	if v, ok := ParseLanguage("Lingala"); !ok || v != LanguageLingala {
+		t.Errorf("%q incorrect", "Lingala")
+	}
+	if v, ok := ParseLanguage("Lithuanian"); !ok || v != LanguageLithuanian {
+		t.Errorf("%q incorrect", "Lithuanian")
+	}
+	if v, ok := ParseLanguage("Macedonian"); !ok || v != LanguageMacedonian {
+		t.Errorf("%q incorrect", "Macedonian")
+	}
+	if v, ok := ParseLanguage("Malagasy"); !ok || v != LanguageMalagasy {
+		t.Errorf("%q incorrect", "Malagasy")
+	}
+	if v, ok := ParseLanguage("Malay"); !ok || v != LanguageMalay {
+		t.Errorf("%q incorrect", "Malay")
+	}
+	if v, ok := ParseLanguage("Malayalam"); !ok || v != LanguageMalayalam {
+		t.Errorf("%q incorrect", "Malayalam")
+	}
+	if v, ok := ParseLanguage("Maltese"); !ok || v != LanguageMaltese {
+		t.Errorf("%q incorrect", "Maltese")
+	}
+	if v, ok := ParseLanguage("Maori"); !ok || v != LanguageMaori {
+		t.Errorf("%q incorrect", "Maori")
+	}
+	if v, ok := ParseLanguage("Marathi"); !ok || v != LanguageMarathi {
+		t.Errorf("%q incorrect", "Marathi")
+	}
+	if v, ok := ParseLanguage("Marshallese"); !ok || v != LanguageMarshallese {
+		t.Errorf("%q incorrect", "Marshallese")
+	}
+	if v, ok := ParseLanguage("Mongolian"); !ok || v != LanguageMongolian {
+		t.Errorf("%q incorrect", "Mongolian")
+	}
+	if v, ok := ParseLanguage("NauruLanguage"); !ok || v != LanguageNauruLanguage {
+		t.Errorf("%q incorrect", "NauruLanguage")
+	}
+	if v, ok := ParseLanguage("Nepali"); !ok || v != LanguageNepali {
+		t.Errorf("%q incorrect", "Nepali")
+	}
+	if v, ok := ParseLanguage("NorwegianBokmal"); !ok || v != LanguageNorwegianBokmal {
+		t.Errorf("%q incorrect", "NorwegianBokmal")
+	}
+	if v, ok := ParseLanguage("Norwegian"); !ok || v != LanguageNorwegian {
+		t.Errorf("%q incorrect", "Norwegian")
+	}
+	if v, ok := ParseLanguage("Occitan"); !ok || v != LanguageOccitan {
+		t.Errorf("%q incorrect", "Occitan")
+	}
+	if v, ok := ParseLanguage("Oriya"); !ok || v != LanguageOriya {
+		t.Errorf("%q incorrect", "Oriya")
+	}
+	if v, ok := ParseLanguage("Pashto"); !ok || v != LanguagePashto {
+		t.Errorf("%q incorrect", "Pashto")
+	}
+	if v, ok := ParseLanguage("Persian"); !ok || v != LanguagePersian {
+		t.Errorf("%q incorrect", "Persian")
+	}
+	if v, ok := ParseLanguage("Polish"); !ok || v != LanguagePolish {
+		t.Errorf("%q incorrect", "Polish")
+	}
+	if v, ok := ParseLanguage("Portuguese"); !ok || v != LanguagePortuguese {
+		t.Errorf("%q incorrect", "Portuguese")
+	}
+	if v, ok := ParseLanguage("Punjabi"); !ok || v != LanguagePunjabi {
+		t.Errorf("%q incorrect", "Punjabi")
+	}
+	if v, ok := ParseLanguage("Quechua"); !ok || v != LanguageQuechua {
+		t.Errorf("%q incorrect", "Quechua")
+	}
+	if v, ok := ParseLanguage("Romansh"); !ok || v != LanguageRomansh {
+		t.Errorf("%q incorrect", "Romansh")
+	}
+	if v, ok := ParseLanguage("RhaetoRomance"); !ok || v != LanguageRhaetoRomance {
+		t.Errorf("%q incorrect", "RhaetoRomance")
+	}
+	if v, ok := ParseLanguage("Moldavian"); !ok || v != LanguageMoldavian {
+		t.Errorf("%q incorrect", "Moldavian")
+	}
+	if v, ok := ParseLanguage("Romanian"); !ok || v != LanguageRomanian {
+		t.Errorf("%q incorrect", "Romanian")
+	}
+	if v, ok := ParseLanguage("Russian"); !ok || v != LanguageRussian {
+		t.Errorf("%q incorrect", "Russian")
+	}
+	if v, ok := ParseLanguage("Samoan"); !ok || v != LanguageSamoan {
+		t.Errorf("%q incorrect", "Samoan")
+	}
+	if v, ok := ParseLanguage("Sango"); !ok || v != LanguageSango {
+		t.Errorf("%q incorrect", "Sango")
+	}
+	if v, ok := ParseLanguage("Sanskrit"); !ok || v != LanguageSanskrit {
+		t.Errorf("%q incorrect", "Sanskrit")
+	}
+	if v, ok := ParseLanguage("SerboCroatian"); !ok || v != LanguageSerboCroatian {
+		t.Errorf("%q incorrect", "SerboCroatian")
+	}
+	if v, ok := ParseLanguage("Serbian"); !ok || v != LanguageSerbian {
+		t.Errorf("%q incorrect", "Serbian")
+	}
+	if v, ok := ParseLanguage("Ossetic"); !ok || v != LanguageOssetic {
+		t.Errorf("%q incorrect", "Ossetic")
+	}
+	if v, ok := ParseLanguage("SouthernSotho"); !ok || v != LanguageSouthernSotho {
+		t.Errorf("%q incorrect", "SouthernSotho")
+	}
+	if v, ok := ParseLanguage("Tswana"); !ok || v != LanguageTswana {
+		t.Errorf("%q incorrect", "Tswana")
+	}
+	if v, ok := ParseLanguage("Shona"); !ok || v != LanguageShona {
+		t.Errorf("%q incorrect", "Shona")
+	}
+	if v, ok := ParseLanguage("Sindhi"); !ok || v != LanguageSindhi {
+		t.Errorf("%q incorrect", "Sindhi")
+	}
+	if v, ok := ParseLanguage("Sinhala"); !ok || v != LanguageSinhala {
+		t.Errorf("%q incorrect", "Sinhala")
+	}
+	if v, ok := ParseLanguage("Swati"); !ok || v != LanguageSwati {
+		t.Errorf("%q incorrect", "Swati")
+	}
+	if v, ok := ParseLanguage("Slovak"); !ok || v != LanguageSlovak {
+		t.Errorf("%q incorrect", "Slovak")
+	}
+	if v, ok := ParseLanguage("Slovenian"); !ok || v != LanguageSlovenian {
+		t.Errorf("%q incorrect", "Slovenian")
+	}
+	if v, ok := ParseLanguage("Somali"); !ok || v != LanguageSomali {
+		t.Errorf("%q incorrect", "Somali")
+	}
+	if v, ok := ParseLanguage("Spanish"); !ok || v != LanguageSpanish {
+		t.Errorf("%q incorrect", "Spanish")
+	}
+	if v, ok := ParseLanguage("Sundanese"); !ok || v != LanguageSundanese {
+		t.Errorf("%q incorrect", "Sundanese")
+	}
+	if v, ok := ParseLanguage("Swahili"); !ok || v != LanguageSwahili {
+		t.Errorf("%q incorrect", "Swahili")
+	}
+	if v, ok := ParseLanguage("Swedish"); !ok || v != LanguageSwedish {
+		t.Errorf("%q incorrect", "Swedish")
+	}
+	if v, ok := ParseLanguage("Sardinian"); !ok || v != LanguageSardinian {
+		t.Errorf("%q incorrect", "Sardinian")
+	}
+	if v, ok := ParseLanguage("Tajik"); !ok || v != LanguageTajik {
+		t.Errorf("%q incorrect", "Tajik")
+	}
+	if v, ok := ParseLanguage("Tamil"); !ok || v != LanguageTamil {
+		t.Errorf("%q incorrect", "Tamil")
+	}
+	if v, ok := ParseLanguage("Tatar"); !ok || v != LanguageTatar {
+		t.Errorf("%q incorrect", "Tatar")
+	}
+	if v, ok := ParseLanguage("Telugu"); !ok || v != LanguageTelugu {
+		t.Errorf("%q incorrect", "Telugu")
+	}
+	if v, ok := ParseLanguage("Thai"); !ok || v != LanguageThai {
+		t.Errorf("%q incorrect", "Thai")
+	}
+	if v, ok := ParseLanguage("Tibetan"); !ok || v != LanguageTibetan {
+		t.Errorf("%q incorrect", "Tibetan")
+	}
+	if v, ok := ParseLanguage("Tigrinya"); !ok || v != LanguageTigrinya {
+		t.Errorf("%q incorrect", "Tigrinya")
+	}
+	if v, ok := ParseLanguage("Tongan"); !ok || v != LanguageTongan {
+		t.Errorf("%q incorrect", "Tongan")
+	}
+	if v, ok := ParseLanguage("Tsonga"); !ok || v != LanguageTsonga {
+		t.Errorf("%q incorrect", "Tsonga")
+	}
+	if v, ok := ParseLanguage("Turkish"); !ok || v != LanguageTurkish {
+		t.Errorf("%q incorrect", "Turkish")
+	}
+	if v, ok := ParseLanguage("Turkmen"); !ok || v != LanguageTurkmen {
+		t.Errorf("%q incorrect", "Turkmen")
+	}
+	if v, ok := ParseLanguage("Tahitian"); !ok || v != LanguageTahitian {
+		t.Errorf("%q incorrect", "Tahitian")
+	}
+	if v, ok := ParseLanguage("Uigur"); !ok || v != LanguageUigur {
+		t.Errorf("%q incorrect", "Uigur")
+	}
+	if v, ok := ParseLanguage("Uighur"); !ok || v != LanguageUighur {
+		t.Errorf("%q incorrect", "Uighur")
+	}
+	if v, ok := ParseLanguage("Ukrainian"); !ok || v != LanguageUkrainian {
+		t.Errorf("%q incorrect", "Ukrainian")
+	}
+	if v, ok := ParseLanguage("Urdu"); !ok || v != LanguageUrdu {
+		t.Errorf("%q incorrect", "Urdu")
+	}
+	if v, ok := ParseLanguage("Uzbek"); !ok || v != LanguageUzbek {
+		t.Errorf("%q incorrect", "Uzbek")
+	}
+	if v, ok := ParseLanguage("Vietnamese"); !ok || v != LanguageVietnamese {
+		t.Errorf("%q incorrect", "Vietnamese")
+	}
+	if v, ok := ParseLanguage("Volapuk"); !ok || v != LanguageVolapuk {
+		t.Errorf("%q incorrect", "Volapuk")
+	}
+	if v, ok := ParseLanguage("Welsh"); !ok || v != LanguageWelsh {
+		t.Errorf("%q incorrect", "Welsh")
+	}
+	if v, ok := ParseLanguage("Wolof"); !ok || v != LanguageWolof {
+		t.Errorf("%q incorrect", "Wolof")
+	}
+	if v, ok := ParseLanguage("Xhosa"); !ok || v != LanguageXhosa {
+		t.Errorf("%q incorrect", "Xhosa")
+	}
+	if v, ok := ParseLanguage("Yiddish"); !ok || v != LanguageYiddish {
+		t.Errorf("%q incorrect", "Yiddish")
+	}
+	if v, ok := ParseLanguage("Yoruba"); !ok || v != LanguageYoruba {
+		t.Errorf("%q incorrect", "Yoruba")
+	}
+	if v, ok := ParseLanguage("Zhuang"); !ok || v != LanguageZhuang {
+		t.Errorf("%q incorrect", "Zhuang")
+	}
+	if v, ok := ParseLanguage("Zulu"); !ok || v != LanguageZulu {
+		t.Errorf("%q incorrect", "Zulu")
+	}
+	if v, ok := ParseLanguage("NorwegianNynorsk"); !ok || v != LanguageNorwegianNynorsk {
+		t.Errorf("%q incorrect", "NorwegianNynorsk")
+	}
+	if v, ok := ParseLanguage("Bosnian"); !ok || v != LanguageBosnian {
+		t.Errorf("%q incorrect", "Bosnian")
+	}
+	if v, ok := ParseLanguage("Divehi"); !ok || v != LanguageDivehi {
+		t.Errorf("%q incorrect", "Divehi")
+	}
+	if v, ok := ParseLanguage("Manx"); !ok || v != LanguageManx {
+		t.Errorf("%q incorrect", "Manx")
+	}
+	if v, ok := ParseLanguage("Cornish"); !ok || v != LanguageCornish {
+		t.Errorf("%q incorrect", "Cornish")
+	}
+	if v, ok := ParseLanguage("Twi"); !ok || v != LanguageTwi {
+		t.Errorf("%q incorrect", "Twi")
+	}
+	if v, ok := ParseLanguage("Akan"); !ok || v != LanguageAkan {
+		t.Errorf("%q incorrect", "Akan")
+	}
+	if v, ok := ParseLanguage("Konkani"); !ok || v != LanguageKonkani {
+		t.Errorf("%q incorrect", "Konkani")
+	}
+	if v, ok := ParseLanguage("Ga"); !ok || v != LanguageGa {
+		t.Errorf("%q incorrect", "Ga")
+	}
+	if v, ok := ParseLanguage("Igbo"); !ok || v != LanguageIgbo {
+		t.Errorf("%q incorrect", "Igbo")
+	}
+	if v, ok := ParseLanguage("Kamba"); !ok || v != LanguageKamba {
+		t.Errorf("%q incorrect", "Kamba")
+	}
+	if v, ok := ParseLanguage("Syriac"); !ok || v != LanguageSyriac {
+		t.Errorf("%q incorrect", "Syriac")
+	}
+	if v, ok := ParseLanguage("Blin"); !ok || v != LanguageBlin {
+		t.Errorf("%q incorrect", "Blin")
+	}
+	if v, ok := ParseLanguage("Geez"); !ok || v != LanguageGeez {
+		t.Errorf("%q incorrect", "Geez")
+	}
+	if v, ok := ParseLanguage("Koro"); !ok || v != LanguageKoro {
+		t.Errorf("%q incorrect", "Koro")
+	}
+	if v, ok := ParseLanguage("Sidamo"); !ok || v != LanguageSidamo {
+		t.Errorf("%q incorrect", "Sidamo")
+	}
+	if v, ok := ParseLanguage("Atsam"); !ok || v != LanguageAtsam {
+		t.Errorf("%q incorrect", "Atsam")
+	}
+	if v, ok := ParseLanguage("Tigre"); !ok || v != LanguageTigre {
+		t.Errorf("%q incorrect", "Tigre")
+	}
+	if v, ok := ParseLanguage("Jju"); !ok || v != LanguageJju {
+		t.Errorf("%q incorrect", "Jju")
+	}
+	if v, ok := ParseLanguage("Friulian"); !ok || v != LanguageFriulian {
+		t.Errorf("%q incorrect", "Friulian")
+	}
+	if v, ok := ParseLanguage("Venda"); !ok || v != LanguageVenda {
+		t.Errorf("%q incorrect", "Venda")
+	}
+	if v, ok := ParseLanguage("Ewe"); !ok || v != LanguageEwe {
+		t.Errorf("%q incorrect", "Ewe")
+	}
+	if v, ok := ParseLanguage("Walamo"); !ok || v != LanguageWalamo {
+		t.Errorf("%q incorrect", "Walamo")
+	}
+	if v, ok := ParseLanguage("Hawaiian"); !ok || v != LanguageHawaiian {
+		t.Errorf("%q incorrect", "Hawaiian")
+	}
+	if v, ok := ParseLanguage("Tyap"); !ok || v != LanguageTyap {
+		t.Errorf("%q incorrect", "Tyap")
+	}
+	if v, ok := ParseLanguage("Nyanja"); !ok || v != LanguageNyanja {
+		t.Errorf("%q incorrect", "Nyanja")
+	}
+	if v, ok := ParseLanguage("Chewa"); !ok || v != LanguageChewa {
+		t.Errorf("%q incorrect", "Chewa")
+	}
+	if v, ok := ParseLanguage("Filipino"); !ok || v != LanguageFilipino {
+		t.Errorf("%q incorrect", "Filipino")
+	}
+	if v, ok := ParseLanguage("Tagalog"); !ok || v != LanguageTagalog {
+		t.Errorf("%q incorrect", "Tagalog")
+	}
+	if v, ok := ParseLanguage("SwissGerman"); !ok || v != LanguageSwissGerman {
+		t.Errorf("%q incorrect", "SwissGerman")
+	}
+	if v, ok := ParseLanguage("SichuanYi"); !ok || v != LanguageSichuanYi {
+		t.Errorf("%q incorrect", "SichuanYi")
+	}
+	if v, ok := ParseLanguage("Kpelle"); !ok || v != LanguageKpelle {
+		t.Errorf("%q incorrect", "Kpelle")
+	}
+	if v, ok := ParseLanguage("LowGerman"); !ok || v != LanguageLowGerman {
+		t.Errorf("%q incorrect", "LowGerman")
+	}
+	if v, ok := ParseLanguage("SouthNdebele"); !ok || v != LanguageSouthNdebele {
+		t.Errorf("%q incorrect", "SouthNdebele")
+	}
+	if v, ok := ParseLanguage("NorthernSotho"); !ok || v != LanguageNorthernSotho {
+		t.Errorf("%q incorrect", "NorthernSotho")
+	}
+	if v, ok := ParseLanguage("NorthernSami"); !ok || v != LanguageNorthernSami {
+		t.Errorf("%q incorrect", "NorthernSami")
+	}
+	if v, ok := ParseLanguage("Taroko"); !ok || v != LanguageTaroko {
+		t.Errorf("%q incorrect", "Taroko")
+	}
+	if v, ok := ParseLanguage("Gusii"); !ok || v != LanguageGusii {
+		t.Errorf("%q incorrect", "Gusii")
+	}
+	if v, ok := ParseLanguage("Taita"); !ok || v != LanguageTaita {
+		t.Errorf("%q incorrect", "Taita")
+	}
+	if v, ok := ParseLanguage("Fulah"); !ok || v != LanguageFulah {
+		t.Errorf("%q incorrect", "Fulah")
+	}
+	if v, ok := ParseLanguage("Kikuyu"); !ok || v != LanguageKikuyu {
+		t.Errorf("%q incorrect", "Kikuyu")
+	}
+	if v, ok := ParseLanguage("Samburu"); !ok || v != LanguageSamburu {
+		t.Errorf("%q incorrect", "Samburu")
+	}
+	if v, ok := ParseLanguage("Sena"); !ok || v != LanguageSena {
+		t.Errorf("%q incorrect", "Sena")
+	}
+	if v, ok := ParseLanguage("NorthNdebele"); !ok || v != LanguageNorthNdebele {
+		t.Errorf("%q incorrect", "NorthNdebele")
+	}
+	if v, ok := ParseLanguage("Rombo"); !ok || v != LanguageRombo {
+		t.Errorf("%q incorrect", "Rombo")
+	}
+	if v, ok := ParseLanguage("Tachelhit"); !ok || v != LanguageTachelhit {
+		t.Errorf("%q incorrect", "Tachelhit")
+	}
+	if v, ok := ParseLanguage("Kabyle"); !ok || v != LanguageKabyle {
+		t.Errorf("%q incorrect", "Kabyle")
+	}
+	if v, ok := ParseLanguage("Nyankole"); !ok || v != LanguageNyankole {
+		t.Errorf("%q incorrect", "Nyankole")
+	}
+	if v, ok := ParseLanguage("Bena"); !ok || v != LanguageBena {
+		t.Errorf("%q incorrect", "Bena")
+	}
+	if v, ok := ParseLanguage("Vunjo"); !ok || v != LanguageVunjo {
+		t.Errorf("%q incorrect", "Vunjo")
+	}
+	if v, ok := ParseLanguage("Bambara"); !ok || v != LanguageBambara {
+		t.Errorf("%q incorrect", "Bambara")
+	}
+	if v, ok := ParseLanguage("Embu"); !ok || v != LanguageEmbu {
+		t.Errorf("%q incorrect", "Embu")
+	}
+	if v, ok := ParseLanguage("Cherokee"); !ok || v != LanguageCherokee {
+		t.Errorf("%q incorrect", "Cherokee")
+	}
+	if v, ok := ParseLanguage("Morisyen"); !ok || v != LanguageMorisyen {
+		t.Errorf("%q incorrect", "Morisyen")
+	}
+	if v, ok := ParseLanguage("Makonde"); !ok || v != LanguageMakonde {
+		t.Errorf("%q incorrect", "Makonde")
+	}
+	if v, ok := ParseLanguage("Langi"); !ok || v != LanguageLangi {
+		t.Errorf("%q incorrect", "Langi")
+	}
+	if v, ok := ParseLanguage("Ganda"); !ok || v != LanguageGanda {
+		t.Errorf("%q incorrect", "Ganda")
+	}
+	if v, ok := ParseLanguage("Bemba"); !ok || v != LanguageBemba {
+		t.Errorf("%q incorrect", "Bemba")
+	}
+	if v, ok := ParseLanguage("Kabuverdianu"); !ok || v != LanguageKabuverdianu {
+		t.Errorf("%q incorrect", "Kabuverdianu")
+	}
+	if v, ok := ParseLanguage("Meru"); !ok || v != LanguageMeru {
+		t.Errorf("%q incorrect", "Meru")
+	}
+	if v, ok := ParseLanguage("Kalenjin"); !ok || v != LanguageKalenjin {
+		t.Errorf("%q incorrect", "Kalenjin")
+	}
+	if v, ok := ParseLanguage("Nama"); !ok || v != LanguageNama {
+		t.Errorf("%q incorrect", "Nama")
+	}
+	if v, ok := ParseLanguage("Machame"); !ok || v != LanguageMachame {
+		t.Errorf("%q incorrect", "Machame")
+	}
+	if v, ok := ParseLanguage("Colognian"); !ok || v != LanguageColognian {
+		t.Errorf("%q incorrect", "Colognian")
+	}
+	if v, ok := ParseLanguage("Masai"); !ok || v != LanguageMasai {
+		t.Errorf("%q incorrect", "Masai")
+	}
+	if v, ok := ParseLanguage("Soga"); !ok || v != LanguageSoga {
+		t.Errorf("%q incorrect", "Soga")
+	}
+	if v, ok := ParseLanguage("Luyia"); !ok || v != LanguageLuyia {
+		t.Errorf("%q incorrect", "Luyia")
+	}
+	if v, ok := ParseLanguage("Asu"); !ok || v != LanguageAsu {
+		t.Errorf("%q incorrect", "Asu")
+	}
+	if v, ok := ParseLanguage("Teso"); !ok || v != LanguageTeso {
+		t.Errorf("%q incorrect", "Teso")
+	}
+	if v, ok := ParseLanguage("Saho"); !ok || v != LanguageSaho {
+		t.Errorf("%q incorrect", "Saho")
+	}
+	if v, ok := ParseLanguage("KoyraChiini"); !ok || v != LanguageKoyraChiini {
+		t.Errorf("%q incorrect", "KoyraChiini")
+	}
+	if v, ok := ParseLanguage("Rwa"); !ok || v != LanguageRwa {
+		t.Errorf("%q incorrect", "Rwa")
+	}
+	if v, ok := ParseLanguage("Luo"); !ok || v != LanguageLuo {
+		t.Errorf("%q incorrect", "Luo")
+	}
+	if v, ok := ParseLanguage("Chiga"); !ok || v != LanguageChiga {
+		t.Errorf("%q incorrect", "Chiga")
+	}
+	if v, ok := ParseLanguage("CentralMoroccoTamazight"); !ok || v != LanguageCentralMoroccoTamazight {
+		t.Errorf("%q incorrect", "CentralMoroccoTamazight")
+	}
+	if v, ok := ParseLanguage("KoyraboroSenni"); !ok || v != LanguageKoyraboroSenni {
+		t.Errorf("%q incorrect", "KoyraboroSenni")
+	}
+	if v, ok := ParseLanguage("Shambala"); !ok || v != LanguageShambala {
+		t.Errorf("%q incorrect", "Shambala")
+	}
+	if v, ok := ParseLanguage("Bodo"); !ok || v != LanguageBodo {
+		t.Errorf("%q incorrect", "Bodo")
+	}
+	if v, ok := ParseLanguage("Avaric"); !ok || v != LanguageAvaric {
+		t.Errorf("%q incorrect", "Avaric")
+	}
+	if v, ok := ParseLanguage("Chamorro"); !ok || v != LanguageChamorro {
+		t.Errorf("%q incorrect", "Chamorro")
+	}
+	if v, ok := ParseLanguage("Chechen"); !ok || v != LanguageChechen {
+		t.Errorf("%q incorrect", "Chechen")
+	}
+	if v, ok := ParseLanguage("Church"); !ok || v != LanguageChurch {
+		t.Errorf("%q incorrect", "Church")
+	}
+	if v, ok := ParseLanguage("Chuvash"); !ok || v != LanguageChuvash {
+		t.Errorf("%q incorrect", "Chuvash")
+	}
+	if v, ok := ParseLanguage("Cree"); !ok || v != LanguageCree {
+		t.Errorf("%q incorrect", "Cree")
+	}
+	if v, ok := ParseLanguage("Haitian"); !ok || v != LanguageHaitian {
+		t.Errorf("%q incorrect", "Haitian")
+	}
+	if v, ok := ParseLanguage("Herero"); !ok || v != LanguageHerero {
+		t.Errorf("%q incorrect", "Herero")
+	}
+	if v, ok := ParseLanguage("HiriMotu"); !ok || v != LanguageHiriMotu {
+		t.Errorf("%q incorrect", "HiriMotu")
+	}
+	if v, ok := ParseLanguage("Kanuri"); !ok || v != LanguageKanuri {
+		t.Errorf("%q incorrect", "Kanuri")
+	}
+	if v, ok := ParseLanguage("Komi"); !ok || v != LanguageKomi {
+		t.Errorf("%q incorrect", "Komi")
+	}
+	if v, ok := ParseLanguage("Kongo"); !ok || v != LanguageKongo {
+		t.Errorf("%q incorrect", "Kongo")
+	}
+	if v, ok := ParseLanguage("Kwanyama"); !ok || v != LanguageKwanyama {
+		t.Errorf("%q incorrect", "Kwanyama")
+	}
+	if v, ok := ParseLanguage("Limburgish"); !ok || v != LanguageLimburgish {
+		t.Errorf("%q incorrect", "Limburgish")
+	}
+	if v, ok := ParseLanguage("LubaKatanga"); !ok || v != LanguageLubaKatanga {
+		t.Errorf("%q incorrect", "LubaKatanga")
+	}
+	if v, ok := ParseLanguage("Luxembourgish"); !ok || v != LanguageLuxembourgish {
+		t.Errorf("%q incorrect", "Luxembourgish")
+	}
+	if v, ok := ParseLanguage("Navaho"); !ok || v != LanguageNavaho {
+		t.Errorf("%q incorrect", "Navaho")
+	}
+	if v, ok := ParseLanguage("Ndonga"); !ok || v != LanguageNdonga {
+		t.Errorf("%q incorrect", "Ndonga")
+	}
+	if v, ok := ParseLanguage("Ojibwa"); !ok || v != LanguageOjibwa {
+		t.Errorf("%q incorrect", "Ojibwa")
+	}
+	if v, ok := ParseLanguage("Pali"); !ok || v != LanguagePali {
+		t.Errorf("%q incorrect", "Pali")
+	}
+	if v, ok := ParseLanguage("Walloon"); !ok || v != LanguageWalloon {
+		t.Errorf("%q incorrect", "Walloon")
+	}
+	if v, ok := ParseLanguage("Aghem"); !ok || v != LanguageAghem {
+		t.Errorf("%q incorrect", "Aghem")
+	}
+	if v, ok := ParseLanguage("Basaa"); !ok || v != LanguageBasaa {
+		t.Errorf("%q incorrect", "Basaa")
+	}
+	if v, ok := ParseLanguage("Zarma"); !ok || v != LanguageZarma {
+		t.Errorf("%q incorrect", "Zarma")
+	}
+	if v, ok := ParseLanguage("Duala"); !ok || v != LanguageDuala {
+		t.Errorf("%q incorrect", "Duala")
+	}
+	if v, ok := ParseLanguage("JolaFonyi"); !ok || v != LanguageJolaFonyi {
+		t.Errorf("%q incorrect", "JolaFonyi")
+	}
+	if v, ok := ParseLanguage("Ewondo"); !ok || v != LanguageEwondo {
+		t.Errorf("%q incorrect", "Ewondo")
+	}
+	if v, ok := ParseLanguage("Bafia"); !ok || v != LanguageBafia {
+		t.Errorf("%q incorrect", "Bafia")
+	}
+	if v, ok := ParseLanguage("MakhuwaMeetto"); !ok || v != LanguageMakhuwaMeetto {
+		t.Errorf("%q incorrect", "MakhuwaMeetto")
+	}
+	if v, ok := ParseLanguage("Mundang"); !ok || v != LanguageMundang {
+		t.Errorf("%q incorrect", "Mundang")
+	}
+	if v, ok := ParseLanguage("Kwasio"); !ok || v != LanguageKwasio {
+		t.Errorf("%q incorrect", "Kwasio")
+	}
+	if v, ok := ParseLanguage("Nuer"); !ok || v != LanguageNuer {
+		t.Errorf("%q incorrect", "Nuer")
+	}
+	if v, ok := ParseLanguage("Sakha"); !ok || v != LanguageSakha {
+		t.Errorf("%q incorrect", "Sakha")
+	}
+	if v, ok := ParseLanguage("Sangu"); !ok || v != LanguageSangu {
+		t.Errorf("%q incorrect", "Sangu")
+	}
+	if v, ok := ParseLanguage("CongoSwahili"); !ok || v != LanguageCongoSwahili {
+		t.Errorf("%q incorrect", "CongoSwahili")
+	}
+	if v, ok := ParseLanguage("Tasawaq"); !ok || v != LanguageTasawaq {
+		t.Errorf("%q incorrect", "Tasawaq")
+	}
+	if v, ok := ParseLanguage("Vai"); !ok || v != LanguageVai {
+		t.Errorf("%q incorrect", "Vai")
+	}
+	if v, ok := ParseLanguage("Walser"); !ok || v != LanguageWalser {
+		t.Errorf("%q incorrect", "Walser")
+	}
+	if v, ok := ParseLanguage("Yangben"); !ok || v != LanguageYangben {
+		t.Errorf("%q incorrect", "Yangben")
+	}
+	if v, ok := ParseLanguage("Avestan"); !ok || v != LanguageAvestan {
+		t.Errorf("%q incorrect", "Avestan")
+	}
+	if v, ok := ParseLanguage("Asturian"); !ok || v != LanguageAsturian {
+		t.Errorf("%q incorrect", "Asturian")
+	}
+	if v, ok := ParseLanguage("Ngomba"); !ok || v != LanguageNgomba {
+		t.Errorf("%q incorrect", "Ngomba")
+	}
+	if v, ok := ParseLanguage("Kako"); !ok || v != LanguageKako {
+		t.Errorf("%q incorrect", "Kako")
+	}
+	if v, ok := ParseLanguage("Meta"); !ok || v != LanguageMeta {
+		t.Errorf("%q incorrect", "Meta")
+	}
+	if v, ok := ParseLanguage("Ngiemboon"); !ok || v != LanguageNgiemboon {
+		t.Errorf("%q incorrect", "Ngiemboon")
+	}
+	if v, ok := ParseLanguage("Aragonese"); !ok || v != LanguageAragonese {
+		t.Errorf("%q incorrect", "Aragonese")
+	}
+	if v, ok := ParseLanguage("Akkadian"); !ok || v != LanguageAkkadian {
+		t.Errorf("%q incorrect", "Akkadian")
+	}
+	if v, ok := ParseLanguage("AncientEgyptian"); !ok || v != LanguageAncientEgyptian {
+		t.Errorf("%q incorrect", "AncientEgyptian")
+	}
+	if v, ok := ParseLanguage("AncientGreek"); !ok || v != LanguageAncientGreek {
+		t.Errorf("%q incorrect", "AncientGreek")
+	}
+	if v, ok := ParseLanguage("Aramaic"); !ok || v != LanguageAramaic {
+		t.Errorf("%q incorrect", "Aramaic")
+	}
+	if v, ok := ParseLanguage("Balinese"); !ok || v != LanguageBalinese {
+		t.Errorf("%q incorrect", "Balinese")
+	}
+	if v, ok := ParseLanguage("Bamun"); !ok || v != LanguageBamun {
+		t.Errorf("%q incorrect", "Bamun")
+	}
+	if v, ok := ParseLanguage("BatakToba"); !ok || v != LanguageBatakToba {
+		t.Errorf("%q incorrect", "BatakToba")
+	}
+	if v, ok := ParseLanguage("Buginese"); !ok || v != LanguageBuginese {
+		t.Errorf("%q incorrect", "Buginese")
+	}
+	if v, ok := ParseLanguage("Buhid"); !ok || v != LanguageBuhid {
+		t.Errorf("%q incorrect", "Buhid")
+	}
+	if v, ok := ParseLanguage("Carian"); !ok || v != LanguageCarian {
+		t.Errorf("%q incorrect", "Carian")
+	}
+	if v, ok := ParseLanguage("Chakma"); !ok || v != LanguageChakma {
+		t.Errorf("%q incorrect", "Chakma")
+	}
+	if v, ok := ParseLanguage("ClassicalMandaic"); !ok || v != LanguageClassicalMandaic {
+		t.Errorf("%q incorrect", "ClassicalMandaic")
+	}
+	if v, ok := ParseLanguage("Coptic"); !ok || v != LanguageCoptic {
+		t.Errorf("%q incorrect", "Coptic")
+	}
+	if v, ok := ParseLanguage("Dogri"); !ok || v != LanguageDogri {
+		t.Errorf("%q incorrect", "Dogri")
+	}
+	if v, ok := ParseLanguage("EasternCham"); !ok || v != LanguageEasternCham {
+		t.Errorf("%q incorrect", "EasternCham")
+	}
+	if v, ok := ParseLanguage("EasternKayah"); !ok || v != LanguageEasternKayah {
+		t.Errorf("%q incorrect", "EasternKayah")
+	}
+	if v, ok := ParseLanguage("Etruscan"); !ok || v != LanguageEtruscan {
+		t.Errorf("%q incorrect", "Etruscan")
+	}
+	if v, ok := ParseLanguage("Gothic"); !ok || v != LanguageGothic {
+		t.Errorf("%q incorrect", "Gothic")
+	}
+	if v, ok := ParseLanguage("Hanunoo"); !ok || v != LanguageHanunoo {
+		t.Errorf("%q incorrect", "Hanunoo")
+	}
+	if v, ok := ParseLanguage("Ingush"); !ok || v != LanguageIngush {
+		t.Errorf("%q incorrect", "Ingush")
+	}
+	if v, ok := ParseLanguage("LargeFloweryMiao"); !ok || v != LanguageLargeFloweryMiao {
+		t.Errorf("%q incorrect", "LargeFloweryMiao")
+	}
+	if v, ok := ParseLanguage("Lepcha"); !ok || v != LanguageLepcha {
+		t.Errorf("%q incorrect", "Lepcha")
+	}
+	if v, ok := ParseLanguage("Limbu"); !ok || v != LanguageLimbu {
+		t.Errorf("%q incorrect", "Limbu")
+	}
+	if v, ok := ParseLanguage("Lisu"); !ok || v != LanguageLisu {
+		t.Errorf("%q incorrect", "Lisu")
+	}
+	if v, ok := ParseLanguage("Lu"); !ok || v != LanguageLu {
+		t.Errorf("%q incorrect", "Lu")
+	}
+	if v, ok := ParseLanguage("Lycian"); !ok || v != LanguageLycian {
+		t.Errorf("%q incorrect", "Lycian")
+	}
+	if v, ok := ParseLanguage("Lydian"); !ok || v != LanguageLydian {
+		t.Errorf("%q incorrect", "Lydian")
+	}
+	if v, ok := ParseLanguage("Mandingo"); !ok || v != LanguageMandingo {
+		t.Errorf("%q incorrect", "Mandingo")
+	}
+	if v, ok := ParseLanguage("Manipuri"); !ok || v != LanguageManipuri {
+		t.Errorf("%q incorrect", "Manipuri")
+	}
+	if v, ok := ParseLanguage("Meroitic"); !ok || v != LanguageMeroitic {
+		t.Errorf("%q incorrect", "Meroitic")
+	}
+	if v, ok := ParseLanguage("NorthernThai"); !ok || v != LanguageNorthernThai {
+		t.Errorf("%q incorrect", "NorthernThai")
+	}
+	if v, ok := ParseLanguage("OldIrish"); !ok || v != LanguageOldIrish {
+		t.Errorf("%q incorrect", "OldIrish")
+	}
+	if v, ok := ParseLanguage("OldNorse"); !ok || v != LanguageOldNorse {
+		t.Errorf("%q incorrect", "OldNorse")
+	}
+	if v, ok := ParseLanguage("OldPersian"); !ok || v != LanguageOldPersian {
+		t.Errorf("%q incorrect", "OldPersian")
+	}
+	if v, ok := ParseLanguage("OldTurkish"); !ok || v != LanguageOldTurkish {
+		t.Errorf("%q incorrect", "OldTurkish")
+	}
+	if v, ok := ParseLanguage("Pahlavi"); !ok || v != LanguagePahlavi {
+		t.Errorf("%q incorrect", "Pahlavi")
+	}
+	if v, ok := ParseLanguage("Parthian"); !ok || v != LanguageParthian {
+		t.Errorf("%q incorrect", "Parthian")
+	}
+	if v, ok := ParseLanguage("Phoenician"); !ok || v != LanguagePhoenician {
+		t.Errorf("%q incorrect", "Phoenician")
+	}
+	if v, ok := ParseLanguage("PrakritLanguage"); !ok || v != LanguagePrakritLanguage {
+		t.Errorf("%q incorrect", "PrakritLanguage")
+	}
+	if v, ok := ParseLanguage("Rejang"); !ok || v != LanguageRejang {
+		t.Errorf("%q incorrect", "Rejang")
+	}
+	if v, ok := ParseLanguage("Sabaean"); !ok || v != LanguageSabaean {
+		t.Errorf("%q incorrect", "Sabaean")
+	}
+	if v, ok := ParseLanguage("Samaritan"); !ok || v != LanguageSamaritan {
+		t.Errorf("%q incorrect", "Samaritan")
+	}
+	if v, ok := ParseLanguage("Santali"); !ok || v != LanguageSantali {
+		t.Errorf("%q incorrect", "Santali")
+	}
+	if v, ok := ParseLanguage("Saurashtra"); !ok || v != LanguageSaurashtra {
+		t.Errorf("%q incorrect", "Saurashtra")
+	}
+	if v, ok := ParseLanguage("Sora"); !ok || v != LanguageSora {
+		t.Errorf("%q incorrect", "Sora")
+	}
+	if v, ok := ParseLanguage("Sylheti"); !ok || v != LanguageSylheti {
+		t.Errorf("%q incorrect", "Sylheti")
+	}
+	if v, ok := ParseLanguage("Tagbanwa"); !ok || v != LanguageTagbanwa {
+		t.Errorf("%q incorrect", "Tagbanwa")
+	}
+	if v, ok := ParseLanguage("TaiDam"); !ok || v != LanguageTaiDam {
+		t.Errorf("%q incorrect", "TaiDam")
+	}
+	if v, ok := ParseLanguage("TaiNua"); !ok || v != LanguageTaiNua {
+		t.Errorf("%q incorrect", "TaiNua")
+	}
+	if v, ok := ParseLanguage("Ugaritic"); !ok || v != LanguageUgaritic {
+		t.Errorf("%q incorrect", "Ugaritic")
+	}
+	if v, ok := ParseLanguage("Akoose"); !ok || v != LanguageAkoose {
+		t.Errorf("%q incorrect", "Akoose")
+	}
+	if v, ok := ParseLanguage("Lakota"); !ok || v != LanguageLakota {
+		t.Errorf("%q incorrect", "Lakota")
+	}
+	if v, ok := ParseLanguage("StandardMoroccanTamazight"); !ok || v != LanguageStandardMoroccanTamazight {
+		t.Errorf("%q incorrect", "StandardMoroccanTamazight")
+	}
+	if v, ok := ParseLanguage("Mapuche"); !ok || v != LanguageMapuche {
+		t.Errorf("%q incorrect", "Mapuche")
+	}
+	if v, ok := ParseLanguage("CentralKurdish"); !ok || v != LanguageCentralKurdish {
+		t.Errorf("%q incorrect", "CentralKurdish")
+	}
+	if v, ok := ParseLanguage("LowerSorbian"); !ok || v != LanguageLowerSorbian {
+		t.Errorf("%q incorrect", "LowerSorbian")
+	}
+	if v, ok := ParseLanguage("UpperSorbian"); !ok || v != LanguageUpperSorbian {
+		t.Errorf("%q incorrect", "UpperSorbian")
+	}
+	if v, ok := ParseLanguage("Kenyang"); !ok || v != LanguageKenyang {
+		t.Errorf("%q incorrect", "Kenyang")
+	}
+	if v, ok := ParseLanguage("Mohawk"); !ok || v != LanguageMohawk {
+		t.Errorf("%q incorrect", "Mohawk")
+	}
+	if v, ok := ParseLanguage("Nko"); !ok || v != LanguageNko {
+		t.Errorf("%q incorrect", "Nko")
+	}
+	if v, ok := ParseLanguage("Prussian"); !ok || v != LanguagePrussian {
+		t.Errorf("%q incorrect", "Prussian")
+	}
+	if v, ok := ParseLanguage("Kiche"); !ok || v != LanguageKiche {
+		t.Errorf("%q incorrect", "Kiche")
+	}
+	if v, ok := ParseLanguage("SouthernSami"); !ok || v != LanguageSouthernSami {
+		t.Errorf("%q incorrect", "SouthernSami")
+	}
+	if v, ok := ParseLanguage("LuleSami"); !ok || v != LanguageLuleSami {
+		t.Errorf("%q incorrect", "LuleSami")
+	}
+	if v, ok := ParseLanguage("InariSami"); !ok || v != LanguageInariSami {
+		t.Errorf("%q incorrect", "InariSami")
+	}
+	if v, ok := ParseLanguage("SkoltSami"); !ok || v != LanguageSkoltSami {
+		t.Errorf("%q incorrect", "SkoltSami")
+	}
+	if v, ok := ParseLanguage("Warlpiri"); !ok || v != LanguageWarlpiri {
+		t.Errorf("%q incorrect", "Warlpiri")
+	}
+	if v, ok := ParseLanguage("ManichaeanMiddlePersian"); !ok || v != LanguageManichaeanMiddlePersian {
+		t.Errorf("%q incorrect", "ManichaeanMiddlePersian")
+	}
+	if v, ok := ParseLanguage("Mende"); !ok || v != LanguageMende {
+		t.Errorf("%q incorrect", "Mende")
+	}
+	if v, ok := ParseLanguage("AncientNorthArabian"); !ok || v != LanguageAncientNorthArabian {
+		t.Errorf("%q incorrect", "AncientNorthArabian")
+	}
+	if v, ok := ParseLanguage("LinearA"); !ok || v != LanguageLinearA {
+		t.Errorf("%q incorrect", "LinearA")
+	}
+	if v, ok := ParseLanguage("HmongNjua"); !ok || v != LanguageHmongNjua {
+		t.Errorf("%q incorrect", "HmongNjua")
+	}
+	if v, ok := ParseLanguage("Ho"); !ok || v != LanguageHo {
+		t.Errorf("%q incorrect", "Ho")
+	}
+	if v, ok := ParseLanguage("Lezghian"); !ok || v != LanguageLezghian {
+		t.Errorf("%q incorrect", "Lezghian")
+	}
+	if v, ok := ParseLanguage("Bassa"); !ok || v != LanguageBassa {
+		t.Errorf("%q incorrect", "Bassa")
+	}
+	if v, ok := ParseLanguage("Mono"); !ok || v != LanguageMono {
+		t.Errorf("%q incorrect", "Mono")
+	}
+	if v, ok := ParseLanguage("TedimChin"); !ok || v != LanguageTedimChin {
+		t.Errorf("%q incorrect", "TedimChin")
+	}
+	if v, ok := ParseLanguage("Maithili"); !ok || v != LanguageMaithili {
+		t.Errorf("%q incorrect", "Maithili")
+	}
+	if v, ok := ParseLanguage("Ahom"); !ok || v != LanguageAhom {
+		t.Errorf("%q incorrect", "Ahom")
+	}
+	if v, ok := ParseLanguage("AmericanSignLanguage"); !ok || v != LanguageAmericanSignLanguage {
+		t.Errorf("%q incorrect", "AmericanSignLanguage")
+	}
+	if v, ok := ParseLanguage("ArdhamagadhiPrakrit"); !ok || v != LanguageArdhamagadhiPrakrit {
+		t.Errorf("%q incorrect", "ArdhamagadhiPrakrit")
+	}
+	if v, ok := ParseLanguage("Bhojpuri"); !ok || v != LanguageBhojpuri {
+		t.Errorf("%q incorrect", "Bhojpuri")
+	}
+	if v, ok := ParseLanguage("HieroglyphicLuwian"); !ok || v != LanguageHieroglyphicLuwian {
+		t.Errorf("%q incorrect", "HieroglyphicLuwian")
+	}
+	if v, ok := ParseLanguage("LiteraryChinese"); !ok || v != LanguageLiteraryChinese {
+		t.Errorf("%q incorrect", "LiteraryChinese")
+	}
+	if v, ok := ParseLanguage("Mazanderani"); !ok || v != LanguageMazanderani {
+		t.Errorf("%q incorrect", "Mazanderani")
+	}
+	if v, ok := ParseLanguage("Mru"); !ok || v != LanguageMru {
+		t.Errorf("%q incorrect", "Mru")
+	}
+	if v, ok := ParseLanguage("Newari"); !ok || v != LanguageNewari {
+		t.Errorf("%q incorrect", "Newari")
+	}
+	if v, ok := ParseLanguage("NorthernLuri"); !ok || v != LanguageNorthernLuri {
+		t.Errorf("%q incorrect", "NorthernLuri")
+	}
+	if v, ok := ParseLanguage("Palauan"); !ok || v != LanguagePalauan {
+		t.Errorf("%q incorrect", "Palauan")
+	}
+	if v, ok := ParseLanguage("Papiamento"); !ok || v != LanguagePapiamento {
+		t.Errorf("%q incorrect", "Papiamento")
+	}
+	if v, ok := ParseLanguage("Saraiki"); !ok || v != LanguageSaraiki {
+		t.Errorf("%q incorrect", "Saraiki")
+	}
+	if v, ok := ParseLanguage("TokelauLanguage"); !ok || v != LanguageTokelauLanguage {
+		t.Errorf("%q incorrect", "TokelauLanguage")
+	}
+	if v, ok := ParseLanguage("TokPisin"); !ok || v != LanguageTokPisin {
+		t.Errorf("%q incorrect", "TokPisin")
+	}
+	if v, ok := ParseLanguage("TuvaluLanguage"); !ok || v != LanguageTuvaluLanguage {
+		t.Errorf("%q incorrect", "TuvaluLanguage")
+	}
+	if v, ok := ParseLanguage("UncodedLanguages"); !ok || v != LanguageUncodedLanguages {
+		t.Errorf("%q incorrect", "UncodedLanguages")
+	}
+	if v, ok := ParseLanguage("Cantonese"); !ok || v != LanguageCantonese {
+		t.Errorf("%q incorrect", "Cantonese")
+	}
+	if v, ok := ParseLanguage("Osage"); !ok || v != LanguageOsage {
+		t.Errorf("%q incorrect", "Osage")
+	}
+	if v, ok := ParseLanguage("Tangut"); !ok || v != LanguageTangut {
+		t.Errorf("%q incorrect", "Tangut")
+	}
+	if v, ok := ParseLanguage("Ido"); !ok || v != LanguageIdo {
+		t.Errorf("%q incorrect", "Ido")
+	}
+	if v, ok := ParseLanguage("Lojban"); !ok || v != LanguageLojban {
+		t.Errorf("%q incorrect", "Lojban")
+	}
+	if v, ok := ParseLanguage("Sicilian"); !ok || v != LanguageSicilian {
+		t.Errorf("%q incorrect", "Sicilian")
+	}
+	if v, ok := ParseLanguage("SouthernKurdish"); !ok || v != LanguageSouthernKurdish {
+		t.Errorf("%q incorrect", "SouthernKurdish")
+	}
+	if v, ok := ParseLanguage("LastLanguage"); !ok || v != LanguageLastLanguage {
+		t.Errorf("%q incorrect", "LastLanguage")
+	}
+	if v, ok := ParseLanguage("WesternBalochi"); !ok || v != LanguageWesternBalochi {
+		t.Errorf("%q incorrect", "WesternBalochi")
+	}
+	if _, ok := ParseLanguage("Invalid"); ok {
+		t.Errorf("%q incorrect", "Invalid")
+	}
+}
+
+func TestParseCountry(t *testing.T) {
+	if v, ok := ParseCountry("AnyCountry"); !ok || v != CountryAnyCountry {
+		t.Errorf("%q incorrect", "AnyCountry")
+	}
+	if v, ok := ParseCountry("Afghanistan"); !ok || v != CountryAfghanistan {
+		t.Errorf("%q incorrect", "Afghanistan")
+	}
+	if v, ok := ParseCountry("Albania"); !ok || v != CountryAlbania {
+		t.Errorf("%q incorrect", "Albania")
+	}
+	if v, ok := ParseCountry("Algeria"); !ok || v != CountryAlgeria {
+		t.Errorf("%q incorrect", "Algeria")
+	}
+	if v, ok := ParseCountry("AmericanSamoa"); !ok || v != CountryAmericanSamoa {
+		t.Errorf("%q incorrect", "AmericanSamoa")
+	}
+	if v, ok := ParseCountry("Andorra"); !ok || v != CountryAndorra {
+		t.Errorf("%q incorrect", "Andorra")
+	}
+	if v, ok := ParseCountry("Angola"); !ok || v != CountryAngola {
+		t.Errorf("%q incorrect", "Angola")
+	}
+	if v, ok := ParseCountry("Anguilla"); !ok || v != CountryAnguilla {
+		t.Errorf("%q incorrect", "Anguilla")
+	}
+	if v, ok := ParseCountry("Antarctica"); !ok || v != CountryAntarctica {
+		t.Errorf("%q incorrect", "Antarctica")
+	}
+	if v, ok := ParseCountry("AntiguaAndBarbuda"); !ok || v != CountryAntiguaAndBarbuda {
+		t.Errorf("%q incorrect", "AntiguaAndBarbuda")
+	}
+	if v, ok := ParseCountry("Argentina"); !ok || v != CountryArgentina {
+		t.Errorf("%q incorrect", "Argentina")
+	}
+	if v, ok := ParseCountry("Armenia"); !ok || v != CountryArmenia {
+		t.Errorf("%q incorrect", "Armenia")
+	}
+	if v, ok := ParseCountry("Aruba"); !ok || v != CountryAruba {
+		t.Errorf("%q incorrect", "Aruba")
+	}
+	if v, ok := ParseCountry("Australia"); !ok || v != CountryAustralia {
+		t.Errorf("%q incorrect", "Australia")
+	}
+	if v, ok := ParseCountry("Austria"); !ok || v != CountryAustria {
+		t.Errorf("%q incorrect", "Austria")
+	}
+	if v, ok := ParseCountry("Azerbaijan"); !ok || v != CountryAzerbaijan {
+		t.Errorf("%q incorrect", "Azerbaijan")
+	}
+	if v, ok := ParseCountry("Bahamas"); !ok || v != CountryBahamas {
+		t.Errorf("%q incorrect", "Bahamas")
+	}
+	if v, ok := ParseCountry("Bahrain"); !ok || v != CountryBahrain {
+		t.Errorf("%q incorrect", "Bahrain")
+	}
+	if v, ok := ParseCountry("Bangladesh"); !ok || v != CountryBangladesh {
+		t.Errorf("%q incorrect", "Bangladesh")
+	}
+	if v, ok := ParseCountry("Barbados"); !ok || v != CountryBarbados {
+		t.Errorf("%q incorrect", "Barbados")
+	}
+	if v, ok := ParseCountry("Belarus"); !ok || v != CountryBelarus {
+		t.Errorf("%q incorrect", "Belarus")
+	}
+	if v, ok := ParseCountry("Belgium"); !ok || v != CountryBelgium {
+		t.Errorf("%q incorrect", "Belgium")
+	}
+	if v, ok := ParseCountry("Belize"); !ok || v != CountryBelize {
+		t.Errorf("%q incorrect", "Belize")
+	}
+	if v, ok := ParseCountry("Benin"); !ok || v != CountryBenin {
+		t.Errorf("%q incorrect", "Benin")
+	}
+	if v, ok := ParseCountry("Bermuda"); !ok || v != CountryBermuda {
+		t.Errorf("%q incorrect", "Bermuda")
+	}
+	if v, ok := ParseCountry("Bhutan"); !ok || v != CountryBhutan {
+		t.Errorf("%q incorrect", "Bhutan")
+	}
+	if v, ok := ParseCountry("Bolivia"); !ok || v != CountryBolivia {
+		t.Errorf("%q incorrect", "Bolivia")
+	}
+	if v, ok := ParseCountry("BosniaAndHerzegowina"); !ok || v != CountryBosniaAndHerzegowina {
+		t.Errorf("%q incorrect", "BosniaAndHerzegowina")
+	}
+	if v, ok := ParseCountry("Botswana"); !ok || v != CountryBotswana {
+		t.Errorf("%q incorrect", "Botswana")
+	}
+	if v, ok := ParseCountry("BouvetIsland"); !ok || v != CountryBouvetIsland {
+		t.Errorf("%q incorrect", "BouvetIsland")
+	}
+	if v, ok := ParseCountry("Brazil"); !ok || v != CountryBrazil {
+		t.Errorf("%q incorrect", "Brazil")
+	}
+	if v, ok := ParseCountry("BritishIndianOceanTerritory"); !ok || v != CountryBritishIndianOceanTerritory {
+		t.Errorf("%q incorrect", "BritishIndianOceanTerritory")
+	}
+	if v, ok := ParseCountry("Brunei"); !ok || v != CountryBrunei {
+		t.Errorf("%q incorrect", "Brunei")
+	}
+	if v, ok := ParseCountry("Bulgaria"); !ok || v != CountryBulgaria {
+		t.Errorf("%q incorrect", "Bulgaria")
+	}
+	if v, ok := ParseCountry("BurkinaFaso"); !ok || v != CountryBurkinaFaso {
+		t.Errorf("%q incorrect", "BurkinaFaso")
+	}
+	if v, ok := ParseCountry("Burundi"); !ok || v != CountryBurundi {
+		t.Errorf("%q incorrect", "Burundi")
+	}
+	if v, ok := ParseCountry("Cambodia"); !ok || v != CountryCambodia {
+		t.Errorf("%q incorrect", "Cambodia")
+	}
+	if v, ok := ParseCountry("Cameroon"); !ok || v != CountryCameroon {
+		t.Errorf("%q incorrect", "Cameroon")
+	}
+	if v, ok := ParseCountry("Canada"); !ok || v != CountryCanada {
+		t.Errorf("%q incorrect", "Canada")
+	}
+	if v, ok := ParseCountry("CapeVerde"); !ok || v != CountryCapeVerde {
+		t.Errorf("%q incorrect", "CapeVerde")
+	}
+	if v, ok := ParseCountry("CaymanIslands"); !ok || v != CountryCaymanIslands {
+		t.Errorf("%q incorrect", "CaymanIslands")
+	}
+	if v, ok := ParseCountry("CentralAfricanRepublic"); !ok || v != CountryCentralAfricanRepublic {
+		t.Errorf("%q incorrect", "CentralAfricanRepublic")
+	}
+	if v, ok := ParseCountry("Chad"); !ok || v != CountryChad {
+		t.Errorf("%q incorrect", "Chad")
+	}
+	if v, ok := ParseCountry("Chile"); !ok || v != CountryChile {
+		t.Errorf("%q incorrect", "Chile")
+	}
+	if v, ok := ParseCountry("China"); !ok || v != CountryChina {
+		t.Errorf("%q incorrect", "China")
+	}
+	if v, ok := ParseCountry("ChristmasIsland"); !ok || v != CountryChristmasIsland {
+		t.Errorf("%q incorrect", "ChristmasIsland")
+	}
+	if v, ok := ParseCountry("CocosIslands"); !ok || v != CountryCocosIslands {
+		t.Errorf("%q incorrect", "CocosIslands")
+	}
+	if v, ok := ParseCountry("Colombia"); !ok || v != CountryColombia {
+		t.Errorf("%q incorrect", "Colombia")
+	}
+	if v, ok := ParseCountry("Comoros"); !ok || v != CountryComoros {
+		t.Errorf("%q incorrect", "Comoros")
+	}
+	if v, ok := ParseCountry("CongoKinshasa"); !ok || v != CountryCongoKinshasa {
+		t.Errorf("%q incorrect", "CongoKinshasa")
+	}
+	if v, ok := ParseCountry("DemocraticRepublicOfCongo"); !ok || v != CountryDemocraticRepublicOfCongo {
+		t.Errorf("%q incorrect", "DemocraticRepublicOfCongo")
+	}
+	if v, ok := ParseCountry("CongoBrazzaville"); !ok || v != CountryCongoBrazzaville {
+		t.Errorf("%q incorrect", "CongoBrazzaville")
+	}
+	if v, ok := ParseCountry("PeoplesRepublicOfCongo"); !ok || v != CountryPeoplesRepublicOfCongo {
+		t.Errorf("%q incorrect", "PeoplesRepublicOfCongo")
+	}
+	if v, ok := ParseCountry("CookIslands"); !ok || v != CountryCookIslands {
+		t.Errorf("%q incorrect", "CookIslands")
+	}
+	if v, ok := ParseCountry("CostaRica"); !ok || v != CountryCostaRica {
+		t.Errorf("%q incorrect", "CostaRica")
+	}
+	if v, ok := ParseCountry("IvoryCoast"); !ok || v != CountryIvoryCoast {
+		t.Errorf("%q incorrect", "IvoryCoast")
+	}
+	if v, ok := ParseCountry("Croatia"); !ok || v != CountryCroatia {
+		t.Errorf("%q incorrect", "Croatia")
+	}
+	if v, ok := ParseCountry("Cuba"); !ok || v != CountryCuba {
+		t.Errorf("%q incorrect", "Cuba")
+	}
+	if v, ok := ParseCountry("Cyprus"); !ok || v != CountryCyprus {
+		t.Errorf("%q incorrect", "Cyprus")
+	}
+	if v, ok := ParseCountry("CzechRepublic"); !ok || v != CountryCzechRepublic {
+		t.Errorf("%q incorrect", "CzechRepublic")
+	}
+	if v, ok := ParseCountry("Denmark"); !ok || v != CountryDenmark {
+		t.Errorf("%q incorrect", "Denmark")
+	}
+	if v, ok := ParseCountry("Djibouti"); !ok || v != CountryDjibouti {
+		t.Errorf("%q incorrect", "Djibouti")
+	}
+	if v, ok := ParseCountry("Dominica"); !ok || v != CountryDominica {
+		t.Errorf("%q incorrect", "Dominica")
+	}
+	if v, ok := ParseCountry("DominicanRepublic"); !ok || v != CountryDominicanRepublic {
+		t.Errorf("%q incorrect", "DominicanRepublic")
+	}
+	if v, ok := ParseCountry("EastTimor"); !ok || v != CountryEastTimor {
+		t.Errorf("%q incorrect", "EastTimor")
+	}
+	if v, ok := ParseCountry("Ecuador"); !ok || v != CountryEcuador {
+		t.Errorf("%q incorrect", "Ecuador")
+	}
+	if v, ok := ParseCountry("Egypt"); !ok || v != CountryEgypt {
+		t.Errorf("%q incorrect", "Egypt")
+	}
+	if v, ok := ParseCountry("ElSalvador"); !ok || v != CountryElSalvador {
+		t.Errorf("%q incorrect", "ElSalvador")
+	}
+	if v, ok := ParseCountry("EquatorialGuinea"); !ok || v != CountryEquatorialGuinea {
+		t.Errorf("%q incorrect", "EquatorialGuinea")
+	}
+	if v, ok := ParseCountry("Eritrea"); !ok || v != CountryEritrea {
+		t.Errorf("%q incorrect", "Eritrea")
+	}
+	if v, ok := ParseCountry("Estonia"); !ok || v != CountryEstonia {
+		t.Errorf("%q incorrect", "Estonia")
+	}
+	if v, ok := ParseCountry("Ethiopia"); !ok || v != CountryEthiopia {
+		t.Errorf("%q incorrect", "Ethiopia")
+	}
+	if v, ok := ParseCountry("FalklandIslands"); !ok || v != CountryFalklandIslands {
+		t.Errorf("%q incorrect", "FalklandIslands")
+	}
+	if v, ok := ParseCountry("FaroeIslands"); !ok || v != CountryFaroeIslands {
+		t.Errorf("%q incorrect", "FaroeIslands")
+	}
+	if v, ok := ParseCountry("Fiji"); !ok || v != CountryFiji {
+		t.Errorf("%q incorrect", "Fiji")
+	}
+	if v, ok := ParseCountry("Finland"); !ok || v != CountryFinland {
+		t.Errorf("%q incorrect", "Finland")
+	}
+	if v, ok := ParseCountry("France"); !ok || v != CountryFrance {
+		t.Errorf("%q incorrect", "France")
+	}
+	if v, ok := ParseCountry("Guernsey"); !ok || v != CountryGuernsey {
+		t.Errorf("%q incorrect", "Guernsey")
+	}
+	if v, ok := ParseCountry("FrenchGuiana"); !ok || v != CountryFrenchGuiana {
+		t.Errorf("%q incorrect", "FrenchGuiana")
+	}
+	if v, ok := ParseCountry("FrenchPolynesia"); !ok || v != CountryFrenchPolynesia {
+		t.Errorf("%q incorrect", "FrenchPolynesia")
+	}
+	if v, ok := ParseCountry("FrenchSouthernTerritories"); !ok || v != CountryFrenchSouthernTerritories {
+		t.Errorf("%q incorrect", "FrenchSouthernTerritories")
+	}
+	if v, ok := ParseCountry("Gabon"); !ok || v != CountryGabon {
+		t.Errorf("%q incorrect", "Gabon")
+	}
+	if v, ok := ParseCountry("Gambia"); !ok || v != CountryGambia {
+		t.Errorf("%q incorrect", "Gambia")
+	}
+	if v, ok := ParseCountry("Georgia"); !ok || v != CountryGeorgia {
+		t.Errorf("%q incorrect", "Georgia")
+	}
+	if v, ok := ParseCountry("Germany"); !ok || v != CountryGermany {
+		t.Errorf("%q incorrect", "Germany")
+	}
+	if v, ok := ParseCountry("Ghana"); !ok || v != CountryGhana {
+		t.Errorf("%q incorrect", "Ghana")
+	}
+	if v, ok := ParseCountry("Gibraltar"); !ok || v != CountryGibraltar {
+		t.Errorf("%q incorrect", "Gibraltar")
+	}
+	if v, ok := ParseCountry("Greece"); !ok || v != CountryGreece {
+		t.Errorf("%q incorrect", "Greece")
+	}
+	if v, ok := ParseCountry("Greenland"); !ok || v != CountryGreenland {
+		t.Errorf("%q incorrect", "Greenland")
+	}
+	if v, ok := ParseCountry("Grenada"); !ok || v != CountryGrenada {
+		t.Errorf("%q incorrect", "Grenada")
+	}
+	if v, ok := ParseCountry("Guadeloupe"); !ok || v != CountryGuadeloupe {
+		t.Errorf("%q incorrect", "Guadeloupe")
+	}
+	if v, ok := ParseCountry("Guam"); !ok || v != CountryGuam {
+		t.Errorf("%q incorrect", "Guam")
+	}
+	if v, ok := ParseCountry("Guatemala"); !ok || v != CountryGuatemala {
+		t.Errorf("%q incorrect", "Guatemala")
+	}
+	if v, ok := ParseCountry("Guinea"); !ok || v != CountryGuinea {
+		t.Errorf("%q incorrect", "Guinea")
+	}
+	if v, ok := ParseCountry("GuineaBissau"); !ok || v != CountryGuineaBissau {
+		t.Errorf("%q incorrect", "GuineaBissau")
+	}
+	if v, ok := ParseCountry("Guyana"); !ok || v != CountryGuyana {
+		t.Errorf("%q incorrect", "Guyana")
+	}
+	if v, ok := ParseCountry("Haiti"); !ok || v != CountryHaiti {
+		t.Errorf("%q incorrect", "Haiti")
+	}
+	if v, ok := ParseCountry("HeardAndMcDonaldIslands"); !ok || v != CountryHeardAndMcDonaldIslands {
+		t.Errorf("%q incorrect", "HeardAndMcDonaldIslands")
+	}
+	if v, ok := ParseCountry("Honduras"); !ok || v != CountryHonduras {
+		t.Errorf("%q incorrect", "Honduras")
+	}
+	if v, ok := ParseCountry("HongKong"); !ok || v != CountryHongKong {
+		t.Errorf("%q incorrect", "HongKong")
+	}
+	if v, ok := ParseCountry("Hungary"); !ok || v != CountryHungary {
+		t.Errorf("%q incorrect", "Hungary")
+	}
+	if v, ok := ParseCountry("Iceland"); !ok || v != CountryIceland {
+		t.Errorf("%q incorrect", "Iceland")
+	}
+	if v, ok := ParseCountry("India"); !ok || v != CountryIndia {
+		t.Errorf("%q incorrect", "India")
+	}
+	if v, ok := ParseCountry("Indonesia"); !ok || v != CountryIndonesia {
+		t.Errorf("%q incorrect", "Indonesia")
+	}
+	if v, ok := ParseCountry("Iran"); !ok || v != CountryIran {
+		t.Errorf("%q incorrect", "Iran")
+	}
+	if v, ok := ParseCountry("Iraq"); !ok || v != CountryIraq {
+		t.Errorf("%q incorrect", "Iraq")
+	}
+	if v, ok := ParseCountry("Ireland"); !ok || v != CountryIreland {
+		t.Errorf("%q incorrect", "Ireland")
+	}
+	if v, ok := ParseCountry("Israel"); !ok || v != CountryIsrael {
+		t.Errorf("%q incorrect", "Israel")
+	}
+	if v, ok := ParseCountry("Italy"); !ok || v != CountryItaly {
+		t.Errorf("%q incorrect", "Italy")
+	}
+	if v, ok := ParseCountry("Jamaica"); !ok || v != CountryJamaica {
+		t.Errorf("%q incorrect", "Jamaica")
+	}
+	if v, ok := ParseCountry("Japan"); !ok || v != CountryJapan {
+		t.Errorf("%q incorrect", "Japan")
+	}
+	if v, ok := ParseCountry("Jordan"); !ok || v != CountryJordan {
+		t.Errorf("%q incorrect", "Jordan")
+	}
+	if v, ok := ParseCountry("Kazakhstan"); !ok || v != CountryKazakhstan {
+		t.Errorf("%q incorrect", "Kazakhstan")
+	}
+	if v, ok := ParseCountry("Kenya"); !ok || v != CountryKenya {
+		t.Errorf("%q incorrect", "Kenya")
+	}
+	if v, ok := ParseCountry("Kiribati"); !ok || v != CountryKiribati {
+		t.Errorf("%q incorrect", "Kiribati")
+	}
+	if v, ok := ParseCountry("NorthKorea"); !ok || v != CountryNorthKorea {
+		t.Errorf("%q incorrect", "NorthKorea")
+	}
+	if v, ok := ParseCountry("DemocraticRepublicOfKorea"); !ok || v != CountryDemocraticRepublicOfKorea {
+		t.Errorf("%q incorrect", "DemocraticRepublicOfKorea")
+	}
+	if v, ok := ParseCountry("SouthKorea"); !ok || v != CountrySouthKorea {
+		t.Errorf("%q incorrect", "SouthKorea")
+	}
+	if v, ok := ParseCountry("RepublicOfKorea"); !ok || v != CountryRepublicOfKorea {
+		t.Errorf("%q incorrect", "RepublicOfKorea")
+	}
+	if v, ok := ParseCountry("Kuwait"); !ok || v != CountryKuwait {
+		t.Errorf("%q incorrect", "Kuwait")
+	}
+	if v, ok := ParseCountry("Kyrgyzstan"); !ok || v != CountryKyrgyzstan {
+		t.Errorf("%q incorrect", "Kyrgyzstan")
+	}
+	if v, ok := ParseCountry("Laos"); !ok || v != CountryLaos {
+		t.Errorf("%q incorrect", "Laos")
+	}
+	if v, ok := ParseCountry("Latvia"); !ok || v != CountryLatvia {
+		t.Errorf("%q incorrect", "Latvia")
+	}
+	if v, ok := ParseCountry("Lebanon"); !ok || v != CountryLebanon {
+		t.Errorf("%q incorrect", "Lebanon")
+	}
+	if v, ok := ParseCountry("Lesotho"); !ok || v != CountryLesotho {
+		t.Errorf("%q incorrect", "Lesotho")
+	}
+	if v, ok := ParseCountry("Liberia"); !ok || v != CountryLiberia {
+		t.Errorf("%q incorrect", "Liberia")
+	}
+	if v, ok := ParseCountry("Libya"); !ok || v != CountryLibya {
+		t.Errorf("%q incorrect", "Libya")
+	}
+	if v, ok := ParseCountry("Liechtenstein"); !ok || v != CountryLiechtenstein {
+		t.Errorf("%q incorrect", "Liechtenstein")
+	}
+	if v, ok := ParseCountry("Lithuania"); !ok || v != CountryLithuania {
+		t.Errorf("%q incorrect", "Lithuania")
+	}
+	if v, ok := ParseCountry("Luxembourg"); !ok || v != CountryLuxembourg {
+		t.Errorf("%q incorrect", "Luxembourg")
+	}
+	if v, ok := ParseCountry("Macau"); !ok || v != CountryMacau {
+		t.Errorf("%q incorrect", "Macau")
+	}
+	if v, ok := ParseCountry("Macedonia"); !ok || v != CountryMacedonia {
+		t.Errorf("%q incorrect", "Macedonia")
+	}
+	if v, ok := ParseCountry("Madagascar"); !ok || v != CountryMadagascar {
+		t.Errorf("%q incorrect", "Madagascar")
+	}
+	if v, ok := ParseCountry("Malawi"); !ok || v != CountryMalawi {
+		t.Errorf("%q incorrect", "Malawi")
+	}
+	if v, ok := ParseCountry("Malaysia"); !ok || v != CountryMalaysia {
+		t.Errorf("%q incorrect", "Malaysia")
+	}
+	if v, ok := ParseCountry("Maldives"); !ok || v != CountryMaldives {
+		t.Errorf("%q incorrect", "Maldives")
+	}
+	if v, ok := ParseCountry("Mali"); !ok || v != CountryMali {
+		t.Errorf("%q incorrect", "Mali")
+	}
+	if v, ok := ParseCountry("Malta"); !ok || v != CountryMalta {
+		t.Errorf("%q incorrect", "Malta")
+	}
+	if v, ok := ParseCountry("MarshallIslands"); !ok || v != CountryMarshallIslands {
+		t.Errorf("%q incorrect", "MarshallIslands")
+	}
+	if v, ok := ParseCountry("Martinique"); !ok || v != CountryMartinique {
+		t.Errorf("%q incorrect", "Martinique")
+	}
+	if v, ok := ParseCountry("Mauritania"); !ok || v != CountryMauritania {
+		t.Errorf("%q incorrect", "Mauritania")
+	}
+	if v, ok := ParseCountry("Mauritius"); !ok || v != CountryMauritius {
+		t.Errorf("%q incorrect", "Mauritius")
+	}
+	if v, ok := ParseCountry("Mayotte"); !ok || v != CountryMayotte {
+		t.Errorf("%q incorrect", "Mayotte")
+	}
+	if v, ok := ParseCountry("Mexico"); !ok || v != CountryMexico {
+		t.Errorf("%q incorrect", "Mexico")
+	}
+	if v, ok := ParseCountry("Micronesia"); !ok || v != CountryMicronesia {
+		t.Errorf("%q incorrect", "Micronesia")
+	}
+	if v, ok := ParseCountry("Moldova"); !ok || v != CountryMoldova {
+		t.Errorf("%q incorrect", "Moldova")
+	}
+	if v, ok := ParseCountry("Monaco"); !ok || v != CountryMonaco {
+		t.Errorf("%q incorrect", "Monaco")
+	}
+	if v, ok := ParseCountry("Mongolia"); !ok || v != CountryMongolia {
+		t.Errorf("%q incorrect", "Mongolia")
+	}
+	if v, ok := ParseCountry("Montserrat"); !ok || v != CountryMontserrat {
+		t.Errorf("%q incorrect", "Montserrat")
+	}
+	if v, ok := ParseCountry("Morocco"); !ok || v != CountryMorocco {
+		t.Errorf("%q incorrect", "Morocco")
+	}
+	if v, ok := ParseCountry("Mozambique"); !ok || v != CountryMozambique {
+		t.Errorf("%q incorrect", "Mozambique")
+	}
+	if v, ok := ParseCountry("Myanmar"); !ok || v != CountryMyanmar {
+		t.Errorf("%q incorrect", "Myanmar")
+	}
+	if v, ok := ParseCountry("Namibia"); !ok || v != CountryNamibia {
+		t.Errorf("%q incorrect", "Namibia")
+	}
+	if v, ok := ParseCountry("NauruCountry"); !ok || v != CountryNauruCountry {
+		t.Errorf("%q incorrect", "NauruCountry")
+	}
+	if v, ok := ParseCountry("Nepal"); !ok || v != CountryNepal {
+		t.Errorf("%q incorrect", "Nepal")
+	}
+	if v, ok := ParseCountry("Netherlands"); !ok || v != CountryNetherlands {
+		t.Errorf("%q incorrect", "Netherlands")
+	}
+	if v, ok := ParseCountry("CuraSao"); !ok || v != CountryCuraSao {
+		t.Errorf("%q incorrect", "CuraSao")
+	}
+	if v, ok := ParseCountry("NewCaledonia"); !ok || v != CountryNewCaledonia {
+		t.Errorf("%q incorrect", "NewCaledonia")
+	}
+	if v, ok := ParseCountry("NewZealand"); !ok || v != CountryNewZealand {
+		t.Errorf("%q incorrect", "NewZealand")
+	}
+	if v, ok := ParseCountry("Nicaragua"); !ok || v != CountryNicaragua {
+		t.Errorf("%q incorrect", "Nicaragua")
+	}
+	if v, ok := ParseCountry("Niger"); !ok || v != CountryNiger {
+		t.Errorf("%q incorrect", "Niger")
+	}
+	if v, ok := ParseCountry("Nigeria"); !ok || v != CountryNigeria {
+		t.Errorf("%q incorrect", "Nigeria")
+	}
+	if v, ok := ParseCountry("Niue"); !ok || v != CountryNiue {
+		t.Errorf("%q incorrect", "Niue")
+	}
+	if v, ok := ParseCountry("NorfolkIsland"); !ok || v != CountryNorfolkIsland {
+		t.Errorf("%q incorrect", "NorfolkIsland")
+	}
+	if v, ok := ParseCountry("NorthernMarianaIslands"); !ok || v != CountryNorthernMarianaIslands {
+		t.Errorf("%q incorrect", "NorthernMarianaIslands")
+	}
+	if v, ok := ParseCountry("Norway"); !ok || v != CountryNorway {
+		t.Errorf("%q incorrect", "Norway")
+	}
+	if v, ok := ParseCountry("Oman"); !ok || v != CountryOman {
+		t.Errorf("%q incorrect", "Oman")
+	}
+	if v, ok := ParseCountry("Pakistan"); !ok || v != CountryPakistan {
+		t.Errorf("%q incorrect", "Pakistan")
+	}
+	if v, ok := ParseCountry("Palau"); !ok || v != CountryPalau {
+		t.Errorf("%q incorrect", "Palau")
+	}
+	if v, ok := ParseCountry("PalestinianTerritories"); !ok || v != CountryPalestinianTerritories {
+		t.Errorf("%q incorrect", "PalestinianTerritories")
+	}
+	if v, ok := ParseCountry("Panama"); !ok || v != CountryPanama {
+		t.Errorf("%q incorrect", "Panama")
+	}
+	if v, ok := ParseCountry("PapuaNewGuinea"); !ok || v != CountryPapuaNewGuinea {
+		t.Errorf("%q incorrect", "PapuaNewGuinea")
+	}
+	if v, ok := ParseCountry("Paraguay"); !ok || v != CountryParaguay {
+		t.Errorf("%q incorrect", "Paraguay")
+	}
+	if v, ok := ParseCountry("Peru"); !ok || v != CountryPeru {
+		t.Errorf("%q incorrect", "Peru")
+	}
+	if v, ok := ParseCountry("Philippines"); !ok || v != CountryPhilippines {
+		t.Errorf("%q incorrect", "Philippines")
+	}
+	if v, ok := ParseCountry("Pitcairn"); !ok || v != CountryPitcairn {
+		t.Errorf("%q incorrect", "Pitcairn")
+	}
+	if v, ok := ParseCountry("Poland"); !ok || v != CountryPoland {
+		t.Errorf("%q incorrect", "Poland")
+	}
+	if v, ok := ParseCountry("Portugal"); !ok || v != CountryPortugal {
+		t.Errorf("%q incorrect", "Portugal")
+	}
+	if v, ok := ParseCountry("PuertoRico"); !ok || v != CountryPuertoRico {
+		t.Errorf("%q incorrect", "PuertoRico")
+	}
+	if v, ok := ParseCountry("Qatar"); !ok || v != CountryQatar {
+		t.Errorf("%q incorrect", "Qatar")
+	}
+	if v, ok := ParseCountry("Reunion"); !ok || v != CountryReunion {
+		t.Errorf("%q incorrect", "Reunion")
+	}
+	if v, ok := ParseCountry("Romania"); !ok || v != CountryRomania {
+		t.Errorf("%q incorrect", "Romania")
+	}
+	if v, ok := ParseCountry("Russia"); !ok || v != CountryRussia {
+		t.Errorf("%q incorrect", "Russia")
+	}
+	if v, ok := ParseCountry("RussianFederation"); !ok || v != CountryRussianFederation {
+		t.Errorf("%q incorrect", "RussianFederation")
+	}
+	if v, ok := ParseCountry("Rwanda"); !ok || v != CountryRwanda {
+		t.Errorf("%q incorrect", "Rwanda")
+	}
+	if v, ok := ParseCountry("SaintKittsAndNevis"); !ok || v != CountrySaintKittsAndNevis {
+		t.Errorf("%q incorrect", "SaintKittsAndNevis")
+	}
+	if v, ok := ParseCountry("SaintLucia"); !ok || v != CountrySaintLucia {
+		t.Errorf("%q incorrect", "SaintLucia")
+	}
+	if v, ok := ParseCountry("SaintVincentAndTheGrenadines"); !ok || v != CountrySaintVincentAndTheGrenadines {
+		t.Errorf("%q incorrect", "SaintVincentAndTheGrenadines")
+	}
+	if v, ok := ParseCountry("Samoa"); !ok || v != CountrySamoa {
+		t.Errorf("%q incorrect", "Samoa")
+	}
+	if v, ok := ParseCountry("SanMarino"); !ok || v != CountrySanMarino {
+		t.Errorf("%q incorrect", "SanMarino")
+	}
+	if v, ok := ParseCountry("SaoTomeAndPrincipe"); !ok || v != CountrySaoTomeAndPrincipe {
+		t.Errorf("%q incorrect", "SaoTomeAndPrincipe")
+	}
+	if v, ok := ParseCountry("SaudiArabia"); !ok || v != CountrySaudiArabia {
+		t.Errorf("%q incorrect", "SaudiArabia")
+	}
+	if v, ok := ParseCountry("Senegal"); !ok || v != CountrySenegal {
+		t.Errorf("%q incorrect", "Senegal")
+	}
+	if v, ok := ParseCountry("Seychelles"); !ok || v != CountrySeychelles {
+		t.Errorf("%q incorrect", "Seychelles")
+	}
+	if v, ok := ParseCountry("SierraLeone"); !ok || v != CountrySierraLeone {
+		t.Errorf("%q incorrect", "SierraLeone")
+	}
+	if v, ok := ParseCountry("Singapore"); !ok || v != CountrySingapore {
+		t.Errorf("%q incorrect", "Singapore")
+	}
+	if v, ok := ParseCountry("Slovakia"); !ok || v != CountrySlovakia {
+		t.Errorf("%q incorrect", "Slovakia")
+	}
+	if v, ok := ParseCountry("Slovenia"); !ok || v != CountrySlovenia {
+		t.Errorf("%q incorrect", "Slovenia")
+	}
+	if v, ok := ParseCountry("SolomonIslands"); !ok || v != CountrySolomonIslands {
+		t.Errorf("%q incorrect", "SolomonIslands")
+	}
+	if v, ok := ParseCountry("Somalia"); !ok || v != CountrySomalia {
+		t.Errorf("%q incorrect", "Somalia")
+	}
+	if v, ok := ParseCountry("SouthAfrica"); !ok || v != CountrySouthAfrica {
+		t.Errorf("%q incorrect", "SouthAfrica")
+	}
+	if v, ok := ParseCountry("SouthGeorgiaAndTheSouthSandwichIslands"); !ok || v != CountrySouthGeorgiaAndTheSouthSandwichIslands {
+		t.Errorf("%q incorrect", "SouthGeorgiaAndTheSouthSandwichIslands")
+	}
+	if v, ok := ParseCountry("Spain"); !ok || v != CountrySpain {
+		t.Errorf("%q incorrect", "Spain")
+	}
+	if v, ok := ParseCountry("SriLanka"); !ok || v != CountrySriLanka {
+		t.Errorf("%q incorrect", "SriLanka")
+	}
+	if v, ok := ParseCountry("SaintHelena"); !ok || v != CountrySaintHelena {
+		t.Errorf("%q incorrect", "SaintHelena")
+	}
+	if v, ok := ParseCountry("SaintPierreAndMiquelon"); !ok || v != CountrySaintPierreAndMiquelon {
+		t.Errorf("%q incorrect", "SaintPierreAndMiquelon")
+	}
+	if v, ok := ParseCountry("Sudan"); !ok || v != CountrySudan {
+		t.Errorf("%q incorrect", "Sudan")
+	}
+	if v, ok := ParseCountry("Suriname"); !ok || v != CountrySuriname {
+		t.Errorf("%q incorrect", "Suriname")
+	}
+	if v, ok := ParseCountry("SvalbardAndJanMayenIslands"); !ok || v != CountrySvalbardAndJanMayenIslands {
+		t.Errorf("%q incorrect", "SvalbardAndJanMayenIslands")
+	}
+	if v, ok := ParseCountry("Swaziland"); !ok || v != CountrySwaziland {
+		t.Errorf("%q incorrect", "Swaziland")
+	}
+	if v, ok := ParseCountry("Sweden"); !ok || v != CountrySweden {
+		t.Errorf("%q incorrect", "Sweden")
+	}
+	if v, ok := ParseCountry("Switzerland"); !ok || v != CountrySwitzerland {
+		t.Errorf("%q incorrect", "Switzerland")
+	}
+	if v, ok := ParseCountry("SyrianArabRepublic"); !ok || v != CountrySyrianArabRepublic {
+		t.Errorf("%q incorrect", "SyrianArabRepublic")
+	}
+	if v, ok := ParseCountry("Syria"); !ok || v != CountrySyria {
+		t.Errorf("%q incorrect", "Syria")
+	}
+	if v, ok := ParseCountry("Taiwan"); !ok || v != CountryTaiwan {
+		t.Errorf("%q incorrect", "Taiwan")
+	}
+	if v, ok := ParseCountry("Tajikistan"); !ok || v != CountryTajikistan {
+		t.Errorf("%q incorrect", "Tajikistan")
+	}
+	if v, ok := ParseCountry("Tanzania"); !ok || v != CountryTanzania {
+		t.Errorf("%q incorrect", "Tanzania")
+	}
+	if v, ok := ParseCountry("Thailand"); !ok || v != CountryThailand {
+		t.Errorf("%q incorrect", "Thailand")
+	}
+	if v, ok := ParseCountry("Togo"); !ok || v != CountryTogo {
+		t.Errorf("%q incorrect", "Togo")
+	}
+	if v, ok := ParseCountry("Tokelau"); !ok || v != CountryTokelau {
+		t.Errorf("%q incorrect", "Tokelau")
+	}
+	if v, ok := ParseCountry("TokelauCountry"); !ok || v != CountryTokelauCountry {
+		t.Errorf("%q incorrect", "TokelauCountry")
+	}
+	if v, ok := ParseCountry("Tonga"); !ok || v != CountryTonga {
+		t.Errorf("%q incorrect", "Tonga")
+	}
+	if v, ok := ParseCountry("TrinidadAndTobago"); !ok || v != CountryTrinidadAndTobago {
+		t.Errorf("%q incorrect", "TrinidadAndTobago")
+	}
+	if v, ok := ParseCountry("Tunisia"); !ok || v != CountryTunisia {
+		t.Errorf("%q incorrect", "Tunisia")
+	}
+	if v, ok := ParseCountry("Turkey"); !ok || v != CountryTurkey {
+		t.Errorf("%q incorrect", "Turkey")
+	}
+	if v, ok := ParseCountry("Turkmenistan"); !ok || v != CountryTurkmenistan {
+		t.Errorf("%q incorrect", "Turkmenistan")
+	}
+	if v, ok := ParseCountry("TurksAndCaicosIslands"); !ok || v != CountryTurksAndCaicosIslands {
+		t.Errorf("%q incorrect", "TurksAndCaicosIslands")
+	}
+	if v, ok := ParseCountry("Tuvalu"); !ok || v != CountryTuvalu {
+		t.Errorf("%q incorrect", "Tuvalu")
+	}
+	if v, ok := ParseCountry("TuvaluCountry"); !ok || v != CountryTuvaluCountry {
+		t.Errorf("%q incorrect", "TuvaluCountry")
+	}
+	if v, ok := ParseCountry("Uganda"); !ok || v != CountryUganda {
+		t.Errorf("%q incorrect", "Uganda")
+	}
+	if v, ok := ParseCountry("Ukraine"); !ok || v != CountryUkraine {
+		t.Errorf("%q incorrect", "Ukraine")
+	}
+	if v, ok := ParseCountry("UnitedArabEmirates"); !ok || v != CountryUnitedArabEmirates {
+		t.Errorf("%q incorrect", "UnitedArabEmirates")
+	}
+	if v, ok := ParseCountry("UnitedKingdom"); !ok || v != CountryUnitedKingdom {
+		t.Errorf("%q incorrect", "UnitedKingdom")
+	}
+	if v, ok := ParseCountry("UnitedStates"); !ok || v != CountryUnitedStates {
+		t.Errorf("%q incorrect", "UnitedStates")
+	}
+	if v, ok := ParseCountry("UnitedStatesMinorOutlyingIslands"); !ok || v != CountryUnitedStatesMinorOutlyingIslands {
+		t.Errorf("%q incorrect", "UnitedStatesMinorOutlyingIslands")
+	}
+	if v, ok := ParseCountry("Uruguay"); !ok || v != CountryUruguay {
+		t.Errorf("%q incorrect", "Uruguay")
+	}
+	if v, ok := ParseCountry("Uzbekistan"); !ok || v != CountryUzbekistan {
+		t.Errorf("%q incorrect", "Uzbekistan")
+	}
+	if v, ok := ParseCountry("Vanuatu"); !ok || v != CountryVanuatu {
+		t.Errorf("%q incorrect", "Vanuatu")
+	}
+	if v, ok := ParseCountry("VaticanCityState"); !ok || v != CountryVaticanCityState {
+		t.Errorf("%q incorrect", "VaticanCityState")
+	}
+	if v, ok := ParseCountry("Venezuela"); !ok || v != CountryVenezuela {
+		t.Errorf("%q incorrect", "Venezuela")
+	}
+	if v, ok := ParseCountry("Vietnam"); !ok || v != CountryVietnam {
+		t.Errorf("%q incorrect", "Vietnam")
+	}
+	if v, ok := ParseCountry("BritishVirginIslands"); !ok || v != CountryBritishVirginIslands {
+		t.Errorf("%q incorrect", "BritishVirginIslands")
+	}
+	if v, ok := ParseCountry("UnitedStatesVirginIslands"); !ok || v != CountryUnitedStatesVirginIslands {
+		t.Errorf("%q incorrect", "UnitedStatesVirginIslands")
+	}
+	if v, ok := ParseCountry("WallisAndFutunaIslands"); !ok || v != CountryWallisAndFutunaIslands {
+		t.Errorf("%q incorrect", "WallisAndFutunaIslands")
+	}
+	if v, ok := ParseCountry("WesternSahara"); !ok || v != CountryWesternSahara {
+		t.Errorf("%q incorrect", "WesternSahara")
+	}
+	if v, ok := ParseCountry("Yemen"); !ok || v != CountryYemen {
+		t.Errorf("%q incorrect", "Yemen")
+	}
+	if v, ok := ParseCountry("CanaryIslands"); !ok || v != CountryCanaryIslands {
+		t.Errorf("%q incorrect", "CanaryIslands")
+	}
+	if v, ok := ParseCountry("Zambia"); !ok || v != CountryZambia {
+		t.Errorf("%q incorrect", "Zambia")
+	}
+	if v, ok := ParseCountry("Zimbabwe"); !ok || v != CountryZimbabwe {
+		t.Errorf("%q incorrect", "Zimbabwe")
+	}
+	if v, ok := ParseCountry("ClippertonIsland"); !ok || v != CountryClippertonIsland {
+		t.Errorf("%q incorrect", "ClippertonIsland")
+	}
+	if v, ok := ParseCountry("Montenegro"); !ok || v != CountryMontenegro {
+		t.Errorf("%q incorrect", "Montenegro")
+	}
+	if v, ok := ParseCountry("Serbia"); !ok || v != CountrySerbia {
+		t.Errorf("%q incorrect", "Serbia")
+	}
+	if v, ok := ParseCountry("SaintBarthelemy"); !ok || v != CountrySaintBarthelemy {
+		t.Errorf("%q incorrect", "SaintBarthelemy")
+	}
+	if v, ok := ParseCountry("SaintMartin"); !ok || v != CountrySaintMartin {
+		t.Errorf("%q incorrect", "SaintMartin")
+	}
+	if v, ok := ParseCountry("LatinAmerica"); !ok || v != CountryLatinAmerica {
+		t.Errorf("%q incorrect", "LatinAmerica")
+	}
+	if v, ok := ParseCountry("LatinAmericaAndTheCaribbean"); !ok || v != CountryLatinAmericaAndTheCaribbean {
+		t.Errorf("%q incorrect", "LatinAmericaAndTheCaribbean")
+	}
+	if v, ok := ParseCountry("AscensionIsland"); !ok || v != CountryAscensionIsland {
+		t.Errorf("%q incorrect", "AscensionIsland")
+	}
+	if v, ok := ParseCountry("AlandIslands"); !ok || v != CountryAlandIslands {
+		t.Errorf("%q incorrect", "AlandIslands")
+	}
+	if v, ok := ParseCountry("DiegoGarcia"); !ok || v != CountryDiegoGarcia {
+		t.Errorf("%q incorrect", "DiegoGarcia")
+	}
+	if v, ok := ParseCountry("CeutaAndMelilla"); !ok || v != CountryCeutaAndMelilla {
+		t.Errorf("%q incorrect", "CeutaAndMelilla")
+	}
+	if v, ok := ParseCountry("IsleOfMan"); !ok || v != CountryIsleOfMan {
+		t.Errorf("%q incorrect", "IsleOfMan")
+	}
+	if v, ok := ParseCountry("Jersey"); !ok || v != CountryJersey {
+		t.Errorf("%q incorrect", "Jersey")
+	}
+	if v, ok := ParseCountry("TristanDaCunha"); !ok || v != CountryTristanDaCunha {
+		t.Errorf("%q incorrect", "TristanDaCunha")
+	}
+	if v, ok := ParseCountry("SouthSudan"); !ok || v != CountrySouthSudan {
+		t.Errorf("%q incorrect", "SouthSudan")
+	}
+	if v, ok := ParseCountry("Bonaire"); !ok || v != CountryBonaire {
+		t.Errorf("%q incorrect", "Bonaire")
+	}
+	if v, ok := ParseCountry("SintMaarten"); !ok || v != CountrySintMaarten {
+		t.Errorf("%q incorrect", "SintMaarten")
+	}
+	if v, ok := ParseCountry("Kosovo"); !ok || v != CountryKosovo {
+		t.Errorf("%q incorrect", "Kosovo")
+	}
+	if v, ok := ParseCountry("EuropeanUnion"); !ok || v != CountryEuropeanUnion {
+		t.Errorf("%q incorrect", "EuropeanUnion")
+	}
+	if v, ok := ParseCountry("OutlyingOceania"); !ok || v != CountryOutlyingOceania {
+		t.Errorf("%q incorrect", "OutlyingOceania")
+	}
+	if v, ok := ParseCountry("World"); !ok || v != CountryWorld {
+		t.Errorf("%q incorrect", "World")
+	}
+	if v, ok := ParseCountry("Europe"); !ok || v != CountryEurope {
+		t.Errorf("%q incorrect", "Europe")
+	}
+	if v, ok := ParseCountry("LastCountry"); !ok || v != CountryLastCountry {
+		t.Errorf("%q incorrect", "LastCountry")
+	}
+	if _, ok := ParseCountry("Invalid"); ok {
+		t.Errorf("%q incorrect", "Invalid")
+	}
+}
@@ -0,0 +1,119 @@
+package qrc
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ConflictPolicy controls what happens when a file is added to a Writer, but a
+// file with the same path and constraints already exists.
+type ConflictPolicy int
+
+const (
+	ConflictError     ConflictPolicy = iota // return an error
+	ConflictKeepFirst                       // keep the existing file
+	ConflictKeepLast                        // replace the existing file
+)
+
+// MergeSource is a Reader to merge, and the prefix to mount it at.
+type MergeSource struct {
+	Prefix string
+	Reader *Reader
+}
+
+// AddReader adds the contents of a Reader under the provided prefix (an empty
+// prefix adds it at the root). File data is copied as-is without being
+// decompressed. Nested RCC files are not expanded.
+func (w *Writer) AddReader(prefix string, r *Reader, conflict ConflictPolicy) error {
+	if _, err := w.mkdirAll(prefix); err != nil {
+		return err
+	}
+	return r.Walk(func(rpath string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return w.addEntry(path.Join(prefix, rpath), entry, conflict)
+	}, false)
+}
+
+// addEntry adds a single ReaderEntry to the Writer without recursing into
+// directories.
+func (w *Writer) addEntry(wpath string, entry *ReaderEntry, conflict ConflictPolicy) error {
+	if entry.IsDir() {
+		if err := w.Mkdir(wpath, entry.ModTime()); err != nil {
+			return err
+		}
+		return nil
+	}
+	buf, err := entry.raw()
+	if err != nil {
+		return fmt.Errorf("read %q: %w", wpath, err)
+	}
+	c, l := entry.Constraints()
+	return w.add(wpath, &writerNode{
+		country:  c,
+		language: l,
+		modTime:  entry.ModTime(),
+		blob: &writerBlob{
+			flags: entry.Flags() & (NodeFlagCompressed | NodeFlagCompressedZstd),
+			data:  buf,
+		},
+	}, conflict)
+}
+
+// Merge combines multiple Readers into a single resource tree. Duplicate
+// files (i.e. with the same path and constraints) are handled according to the
+// ConflictPolicy, with sources earlier in the list being added first.
+func Merge(conflict ConflictPolicy, src ...MergeSource) (*Writer, error) {
+	w := NewWriter()
+	for i, s := range src {
+		if err := w.AddReader(s.Prefix, s.Reader, conflict); err != nil {
+			return nil, fmt.Errorf("merge source %d (prefix %q): %w", i, s.Prefix, err)
+		}
+	}
+	return w, nil
+}
+
+// Split splits a Reader into a separate resource tree for each top-level
+// directory, keyed by the directory name. Files in the root are put in a tree
+// with an empty key. If there is an entry in prefix for the key, the top-level
+// directory is replaced with it (so an empty prefix removes the top-level
+// directory, and the resources would need to be registered with the original
+// directory as the mount prefix to keep the original paths). Otherwise, the
+// paths are kept as-is.
+func Split(r *Reader, prefix map[string]string) (map[string]*Writer, error) {
+	ws := map[string]*Writer{}
+	if err := r.Walk(func(rpath string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		var key, rel string
+		if i := strings.IndexByte(rpath, '/'); i != -1 {
+			key, rel = rpath[:i], rpath[i+1:]
+		} else if entry.IsDir() {
+			key, rel = rpath, ""
+		} else {
+			key, rel = "", rpath
+		}
+
+		w, ok := ws[key]
+		if !ok {
+			w = NewWriter()
+			ws[key] = w
+		}
+
+		wpath := rpath
+		if p, ok := prefix[key]; ok {
+			wpath = path.Join(p, rel)
+		}
+		if wpath == "" || wpath == "." {
+			return nil // top-level dir mounted at the root
+		}
+		return w.addEntry(wpath, entry, ConflictError)
+	}, false); err != nil {
+		return nil, fmt.Errorf("split: %w", err)
+	}
+	return ws, nil
+}
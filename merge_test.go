@@ -0,0 +1,117 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	w1 := NewWriter()
+	testAdd(t, w1, "a.txt", LanguageC, "a1")
+	testAdd(t, w1, "x/b.txt", LanguageC, "b1")
+	r1 := testReader(t, w1, 2)
+
+	w2 := NewWriter()
+	testAdd(t, w2, "a.txt", LanguageC, "a2")
+	testAdd(t, w2, "a.txt", LanguageGerman, "a2 (de)")
+	r2 := testReader(t, w2, 2)
+
+	if _, err := Merge(ConflictError, MergeSource{"", r1}, MergeSource{"", r2}); err == nil {
+		t.Errorf("expected conflict error")
+	}
+
+	for _, c := range []struct {
+		conflict ConflictPolicy
+		exp      string
+	}{
+		{ConflictKeepFirst, "a1"},
+		{ConflictKeepLast, "a2"},
+	} {
+		w, err := Merge(c.conflict, MergeSource{"", r1}, MergeSource{"", r2})
+		if err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+		testFilesEqual(t, testReader(t, w, 2), map[string]string{
+			"a.txt":                  c.exp,
+			"a[language!German].txt": "a2 (de)",
+			"x/b.txt":                "b1",
+		})
+	}
+
+	w, err := Merge(ConflictError, MergeSource{"one", r1}, MergeSource{"two/three", r2})
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	testFilesEqual(t, testReader(t, w, 2), map[string]string{
+		"one/a.txt":                        "a1",
+		"one/x/b.txt":                      "b1",
+		"two/three/a.txt":                  "a2",
+		"two/three/a[language!German].txt": "a2 (de)",
+	})
+
+	var buf bytes.Buffer
+	if err := w2.WriteRCC(&buf, 2); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+	r, err := NewReaderFromRCCWithOptions(bytes.NewReader(buf.Bytes()), &ReaderOptions{MaxFileSize: 4})
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+	if err := NewWriter().AddReader("", r, ConflictError); !errors.Is(err, ErrMaxFileSize) {
+		t.Errorf("expected ErrMaxFileSize for file larger than MaxFileSize, got %v", err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	w := NewWriter()
+	testAdd(t, w, "a.txt", LanguageC, "a")
+	testAdd(t, w, "x/b.txt", LanguageC, "b")
+	testAdd(t, w, "x/y/c.txt", LanguageC, "c")
+	testAdd(t, w, "z/d.txt", LanguageC, "d")
+	r := testReader(t, w, 2)
+
+	for _, c := range []struct {
+		prefix map[string]string
+		files  map[string]map[string]string
+	}{
+		{nil, map[string]map[string]string{
+			"":  {"a.txt": "a"},
+			"x": {"x/b.txt": "b", "x/y/c.txt": "c"},
+			"z": {"z/d.txt": "d"},
+		}},
+		{map[string]string{"x": "", "z": ""}, map[string]map[string]string{
+			"":  {"a.txt": "a"},
+			"x": {"b.txt": "b", "y/c.txt": "c"},
+			"z": {"d.txt": "d"},
+		}},
+		{map[string]string{"": "root", "x": "one/two"}, map[string]map[string]string{
+			"":  {"root/a.txt": "a"},
+			"x": {"one/two/b.txt": "b", "one/two/y/c.txt": "c"},
+			"z": {"z/d.txt": "d"},
+		}},
+	} {
+		ws, err := Split(r, c.prefix)
+		if err != nil {
+			t.Fatalf("split: %v", err)
+		}
+		if len(ws) != len(c.files) {
+			t.Errorf("expected %d trees, got %d", len(c.files), len(ws))
+		}
+		for key, files := range c.files {
+			if w, ok := ws[key]; !ok {
+				t.Errorf("missing tree %q", key)
+			} else {
+				testFilesEqual(t, testReader(t, w, 2), files)
+			}
+		}
+	}
+}
+
+func testAdd(t *testing.T, w *Writer, path string, language Language, data string) {
+	t.Helper()
+	if err := w.Add(path, CountryAnyCountry, language, time.Time{}, []byte(data)); err != nil {
+		t.Fatalf("add %q: %v", path, err)
+	}
+}
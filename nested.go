@@ -0,0 +1,201 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// IsRCC checks whether the contents of the file (after decompression) start
+// with RCCHeaderMagic. If the entry is a directory, false is returned. Errors
+// are returned as an *Error.
+func (e ReaderEntry) IsRCC() (bool, error) {
+	if e.IsDir() {
+		return false, nil
+	}
+	f, err := e.Open()
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var buf [4]byte
+	if _, err := io.ReadFull(f, buf[:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil
+		}
+		return false, err
+	}
+	return buf == RCCHeaderMagic, nil
+}
+
+// nestedRCC checks whether Walk should treat the file as a nested RCC file,
+// and whether it was only detected by the magic (in which case it should be
+// treated as a regular file if it can't be opened).
+func (e ReaderEntry) nestedRCC(path string) (nested, sniffed bool) {
+	if fn := e.r.opt.NestedFunc; fn != nil {
+		return fn(path, &e), false
+	}
+	if filepath.Ext(path) == ".rcc" {
+		return true, false
+	}
+	if e.r.opt.NestedSniff {
+		ok, _ := e.IsRCC() // if it can't be read, the error will be returned when it is opened as a file instead
+		return ok, ok
+	}
+	return false, false
+}
+
+// OpenNestedRCC is like OpenRCC, but only opens the file if Walk would treat
+// it as a nested RCC file at path (see ReaderOptions.NestedFunc and
+// ReaderOptions.NestedSniff). If it isn't one, nil is returned without an
+// error. If it was only detected by the magic and can't be opened (other than
+// due to MaxNestedDepth), it is treated as a regular file which just happens to
+// start with the magic, so nil is also returned without an error.
+func (e ReaderEntry) OpenNestedRCC(path string) (*Reader, error) {
+	if e.IsDir() {
+		return nil, nil
+	}
+	nested, sniffed := e.nestedRCC(path)
+	if !nested {
+		return nil, nil
+	}
+	r, err := e.OpenRCC()
+	if err != nil && sniffed && !errors.Is(err, ErrMaxNestedDepth) {
+		return nil, nil
+	}
+	return r, err
+}
+
+// OpenRCC opens the contents of the file as a nested RCC file, sharing the
+// limits and options with the Reader. Stored files are read directly from the
+// parent. Compressed files are decompressed into memory, or into a temporary
+// file if the uncompressed size is larger than ReaderOptions.SpillSize. The
+// nested Reader should be closed to remove the temporary file when done. If
+// MaxNestedDepth is exceeded, an error wrapping ErrMaxNestedDepth is returned.
+func (e ReaderEntry) OpenRCC() (*Reader, error) {
+	if max := e.r.opt.MaxNestedDepth; max >= 0 && e.r.nested+1 > max {
+		return nil, fmt.Errorf("%w (%d)", ErrMaxNestedDepth, max)
+	}
+
+	var (
+		ra      io.ReaderAt
+		base    = int64(-1)
+		release func() error
+	)
+	if e.Compression() == CompressionNone {
+		sz, err := e.Size()
+		if err != nil {
+			return nil, err
+		}
+		if err := e.r.data().check(e.n.fileDataOffset(), sz); err != nil {
+			return nil, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), err)
+		}
+		ra = io.NewSectionReader(e.r.data(), e.n.fileDataOffset(), sz)
+		if e.r.base >= 0 {
+			base = e.r.base + e.Offset()
+		}
+	} else {
+		f, err := e.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if ra, release, err = e.r.opt.spill(f); err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := NewReaderFromRCCWithOptions(ra, &e.r.opt)
+	if err != nil {
+		if release != nil {
+			release()
+		}
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	r.limits = e.r.limits
+	r.nested = e.r.nested + 1
+	r.parent = &e
+	r.base = base
+	r.release = release
+	return r, nil
+}
+
+// spill reads r into memory, or into a temporary file in SpillDir if it is
+// larger than SpillSize. The size isn't trusted, so only up to SpillSize+1
+// bytes are read into memory before switching to the temporary file. If one
+// was used, release closes and removes it.
+func (o ReaderOptions) spill(r io.Reader) (ra io.ReaderAt, release func() error, err error) {
+	lr := r
+	if o.SpillSize > 0 {
+		lr = io.LimitReader(r, o.SpillSize+1)
+	}
+	buf, err := ioutil.ReadAll(lr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read into memory: %w", err)
+	}
+	if o.SpillSize <= 0 || int64(len(buf)) <= o.SpillSize {
+		return bytes.NewReader(buf), nil, nil
+	}
+	tf, err := ioutil.TempFile(o.SpillDir, "qrc-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("create temp file: %w", err)
+	}
+	release = func() error {
+		err := tf.Close()
+		if rerr := os.Remove(tf.Name()); err == nil {
+			err = rerr
+		}
+		return err
+	}
+	if _, err := io.Copy(tf, io.MultiReader(bytes.NewReader(buf), r)); err != nil {
+		release()
+		return nil, nil, fmt.Errorf("read into temp file: %w", err)
+	}
+	return tf, release, nil
+}
+
+// Close removes the temporary file for a nested Reader opened by OpenRCC, if
+// any. It does nothing for other readers.
+func (r *Reader) Close() error {
+	if r.release == nil {
+		return nil
+	}
+	err := r.release()
+	r.release = nil
+	return err
+}
+
+// Parent returns the entry the Reader was opened from with OpenRCC, or nil if
+// it isn't a nested RCC file.
+func (r *Reader) Parent() *ReaderEntry {
+	return r.parent
+}
+
+// NestedDepth returns the number of nested RCC files containing the Reader,
+// i.e. zero if it isn't a nested RCC file.
+func (r *Reader) NestedDepth() int {
+	return r.nested
+}
+
+// Reader returns the Reader the entry was read from. For entries in a nested
+// RCC file, Reader().Parent() is the file in the containing Reader.
+func (e ReaderEntry) Reader() *Reader {
+	return e.r
+}
+
+// AbsOffset is like Offset, but for entries in nested RCC files, it is
+// relative to the io.ReaderAt of the outermost Reader. If a containing nested
+// RCC file is compressed, there isn't an absolute offset, and false is
+// returned.
+func (e ReaderEntry) AbsOffset() (int64, bool) {
+	if e.r.base < 0 {
+		return 0, false
+	}
+	return e.r.base + e.Offset(), true
+}
@@ -0,0 +1,335 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNestedRCC(t *testing.T) {
+	build := testBuildRCC(t)
+	inner := build(CompressionNone, map[string][]byte{"a.txt": []byte("hello"), "b/c.txt": bytes.Repeat([]byte("x"), 1000)})
+
+	dir, err := ioutil.TempDir("", "qrc")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, c := range []struct {
+		what  string
+		c     Compression
+		opt   ReaderOptions
+		spill bool
+	}{
+		{"stored", CompressionNone, ReaderOptions{}, false},
+		{"zlib", CompressionZlib, ReaderOptions{}, false},
+		{"zstd", CompressionZstd, ReaderOptions{}, false},
+		{"zlib spill", CompressionZlib, ReaderOptions{SpillSize: 100, SpillDir: dir}, true},
+		{"zstd spill", CompressionZstd, ReaderOptions{SpillSize: 100, SpillDir: dir}, true},
+		{"zlib below spill size", CompressionZlib, ReaderOptions{SpillSize: int64(len(inner)), SpillDir: dir}, false},
+	} {
+		opt := c.opt
+		r, err := NewReaderFromRCCWithOptions(bytes.NewReader(build(c.c, map[string][]byte{"nested.rcc": inner})), &opt)
+		if err != nil {
+			t.Fatalf("%s: read rcc: %v", c.what, err)
+		}
+		e, err := r.Stat("nested.rcc", CountryAnyCountry, LanguageC)
+		if err != nil {
+			t.Fatalf("%s: stat: %v", c.what, err)
+		}
+		if e.Compression() != c.c {
+			t.Fatalf("%s: expected compression %s, got %s", c.what, c.c, e.Compression())
+		}
+
+		nr, err := e.OpenRCC()
+		if err != nil {
+			t.Fatalf("%s: open nested rcc: %v", c.what, err)
+		}
+		switch nr.reader.(type) {
+		case *io.SectionReader:
+			if c.c != CompressionNone {
+				t.Errorf("%s: expected compressed rcc not to be read from the parent", c.what)
+			}
+		case *os.File:
+			if !c.spill {
+				t.Errorf("%s: expected rcc not to be spilled to a file", c.what)
+			}
+		case *bytes.Reader:
+			if c.c == CompressionNone || c.spill {
+				t.Errorf("%s: expected rcc not to be read into memory", c.what)
+			}
+		default:
+			t.Errorf("%s: unexpected reader %T", c.what, nr.reader)
+		}
+		if nr.nested != 1 || nr.limits != r.limits {
+			t.Errorf("%s: expected nested rcc to share limits", c.what)
+		}
+		testFilesEqual(t, nr, map[string]string{
+			"a.txt":   "hello",
+			"b/c.txt": string(bytes.Repeat([]byte("x"), 1000)),
+		})
+		if fs, _ := ioutil.ReadDir(dir); c.spill && len(fs) != 1 {
+			t.Errorf("%s: expected temp file, got %d files", c.what, len(fs))
+		}
+		if err := nr.Close(); err != nil {
+			t.Errorf("%s: close: unexpected error: %v", c.what, err)
+		}
+		if fs, _ := ioutil.ReadDir(dir); len(fs) != 0 {
+			t.Errorf("%s: expected temp file to be removed, got %d files", c.what, len(fs))
+		}
+
+		var paths []string
+		if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			paths = append(paths, path)
+			return nil
+		}, true); err != nil {
+			t.Errorf("%s: walk: unexpected error: %v", c.what, err)
+		} else if len(paths) != 4 {
+			t.Errorf("%s: walk: expected 4 entries, got %q", c.what, paths)
+		}
+		if fs, _ := ioutil.ReadDir(dir); len(fs) != 0 {
+			t.Errorf("%s: walk: expected temp file to be removed, got %d files", c.what, len(fs))
+		}
+	}
+
+	// the qCompress header can't be trusted to decide whether to spill
+	rcc := build(CompressionZlib, map[string][]byte{"nested.rcc": inner})
+	h, err := ParseRCCHeader(bytes.NewReader(rcc))
+	if err != nil {
+		t.Fatalf("parse header: %v", err)
+	}
+	copy(rcc[h.DataOffset+4:], []byte{0, 0, 0, 1})
+	r, err := NewReaderFromRCCWithOptions(bytes.NewReader(rcc), &ReaderOptions{SpillSize: 100, SpillDir: dir})
+	if err != nil {
+		t.Fatalf("bad header: read rcc: %v", err)
+	}
+	e, err := r.Stat("nested.rcc", CountryAnyCountry, LanguageC)
+	if err != nil {
+		t.Fatalf("bad header: stat: %v", err)
+	}
+	nr, err := e.OpenRCC()
+	if err != nil {
+		t.Fatalf("bad header: open nested rcc: %v", err)
+	}
+	if _, ok := nr.reader.(*os.File); !ok {
+		t.Errorf("bad header: expected rcc to be spilled to a file, got %T", nr.reader)
+	}
+	nr.Close()
+}
+
+// testBuildRCC returns a function which writes a RCC file containing the files
+// compressed with c.
+func testBuildRCC(t testing.TB) func(c Compression, files map[string][]byte) []byte {
+	return func(c Compression, files map[string][]byte) []byte {
+		w := NewWriter()
+		for p, d := range files {
+			if err := w.Add(p, CountryAnyCountry, LanguageC, time.Time{}, d); err != nil {
+				t.Fatalf("add %q: %v", p, err)
+			}
+		}
+		if err := w.Compress(CompressionPolicy{CompressionSettings: CompressionSettings{Compression: c}}); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := w.WriteRCC(&buf, 3); err != nil {
+			t.Fatalf("write rcc: %v", err)
+		}
+		return buf.Bytes()
+	}
+}
+
+func TestNestedSniff(t *testing.T) {
+	build := testBuildRCC(t)
+	inner := build(CompressionNone, map[string][]byte{"x": []byte("x")})
+	outer := build(CompressionZlib, map[string][]byte{
+		"a.rcc":    inner,
+		"blob.dat": inner,
+		"noext":    inner,
+		"text.txt": []byte("qre"),
+		"qres.txt": []byte("qres"),
+	})
+
+	for _, c := range []struct {
+		what string
+		opt  ReaderOptions
+		exp  string
+	}{
+		{"extension", ReaderOptions{}, "a.rcc,a.rcc/x,noext,blob.dat,qres.txt,text.txt"},
+		{"sniff", ReaderOptions{NestedSniff: true}, "a.rcc,a.rcc/x,noext,noext/x,blob.dat,blob.dat/x,qres.txt,text.txt"},
+		{"func", ReaderOptions{NestedSniff: true, NestedFunc: func(path string, entry *ReaderEntry) bool {
+			return path == "blob.dat"
+		}}, "a.rcc,noext,blob.dat,blob.dat/x,qres.txt,text.txt"},
+	} {
+		opt := c.opt
+		r, err := NewReaderFromRCCWithOptions(bytes.NewReader(outer), &opt)
+		if err != nil {
+			t.Fatalf("%s: read rcc: %v", c.what, err)
+		}
+		var paths []string
+		if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+			if err != nil {
+				t.Errorf("%s: walk: %s: unexpected error: %v", c.what, path, err)
+				path += "!"
+			}
+			paths = append(paths, path)
+			return nil
+		}, true); err != nil {
+			t.Errorf("%s: walk: unexpected error: %v", c.what, err)
+		} else if x := strings.Join(paths, ","); x != c.exp {
+			t.Errorf("%s: walk: expected %q, got %q", c.what, c.exp, x)
+		}
+
+		paths = paths[:0]
+		it := r.Iter(true, IterTreeOrder)
+		for {
+			_, path, err := it.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Errorf("%s: iter: %s: unexpected error: %v", c.what, path, err)
+				path += "!"
+			}
+			paths = append(paths, path)
+		}
+		it.Close()
+		if x := strings.Join(paths, ","); x != c.exp {
+			t.Errorf("%s: iter: expected %q, got %q", c.what, c.exp, x)
+		}
+	}
+
+	s, err := NewStreamReader(bytes.NewReader(outer), &ReaderOptions{NestedSniff: true})
+	if err != nil {
+		t.Fatalf("stream: new stream reader: %v", err)
+	}
+	for {
+		e, p, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream: next: %v", err)
+		}
+		nr, err := e.OpenNestedRCC(p)
+		if err != nil {
+			t.Errorf("stream: %s: unexpected error: %v", p, err)
+		} else if exp := strings.HasSuffix(p, ".rcc") || p == "noext" || p == "blob.dat"; (nr != nil) != exp {
+			t.Errorf("stream: %s: expected nested rcc to be %t", p, exp)
+		}
+		if nr != nil {
+			nr.Close()
+		}
+	}
+	s.Close()
+
+	r, err := NewReaderFromRCC(bytes.NewReader(outer))
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+	for p, exp := range map[string]bool{"a.rcc": true, "noext": true, "text.txt": false, "qres.txt": true, "": false} {
+		e, err := r.Stat(p, CountryAnyCountry, LanguageC)
+		if err != nil {
+			t.Fatalf("stat %q: %v", p, err)
+		}
+		if ok, err := e.IsRCC(); err != nil {
+			t.Errorf("%q: is rcc: unexpected error: %v", p, err)
+		} else if ok != exp {
+			t.Errorf("%q: is rcc: expected %t, got %t", p, exp, ok)
+		}
+	}
+}
+
+func TestNestedProvenance(t *testing.T) {
+	build := testBuildRCC(t)
+	deep := build(CompressionNone, map[string][]byte{"x": []byte("hello")})
+	inner := build(CompressionNone, map[string][]byte{"deep.rcc": deep})
+
+	for _, c := range []struct {
+		what string
+		c    Compression
+		abs  bool
+	}{
+		{"stored", CompressionNone, true},
+		{"compressed", CompressionZlib, false},
+	} {
+		outer := build(c.c, map[string][]byte{"a/inner.rcc": inner, "b": []byte("world")})
+		r, err := NewReaderFromRCC(bytes.NewReader(outer))
+		if err != nil {
+			t.Fatalf("%s: read rcc: %v", c.what, err)
+		}
+
+		var found bool
+		if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.Path() != path {
+				t.Errorf("%s: %s: expected path to match, got %q", c.what, path, entry.Path())
+			}
+			switch path {
+			case "b":
+				if entry.Reader() != r || r.Parent() != nil || r.NestedDepth() != 0 {
+					t.Errorf("%s: %s: incorrect provenance", c.what, path)
+				}
+				if off, ok := entry.AbsOffset(); !ok || off != entry.Offset() {
+					t.Errorf("%s: %s: expected absolute offset to match offset", c.what, path)
+				}
+			case "a/inner.rcc/deep.rcc/x":
+				found = true
+				nr := entry.Reader()
+				if nr.NestedDepth() != 2 {
+					t.Errorf("%s: %s: expected nested depth 2, got %d", c.what, path, nr.NestedDepth())
+				}
+				if p := nr.Parent(); p == nil || p.Path() != "a/inner.rcc/deep.rcc" || p.Reader().NestedDepth() != 1 {
+					t.Errorf("%s: %s: incorrect parent", c.what, path)
+				} else if p := p.Reader().Parent(); p == nil || p.Path() != "a/inner.rcc" || p.Reader() != r {
+					t.Errorf("%s: %s: incorrect grandparent", c.what, path)
+				}
+				if off, ok := entry.AbsOffset(); ok != c.abs {
+					t.Errorf("%s: %s: expected absolute offset %t, got %t", c.what, path, c.abs, ok)
+				} else if ok && string(outer[off:off+5]) != "hello" {
+					t.Errorf("%s: %s: incorrect absolute offset %#x", c.what, path, off)
+				}
+			}
+			return nil
+		}, true); err != nil {
+			t.Fatalf("%s: walk: %v", c.what, err)
+		}
+		if !found {
+			t.Errorf("%s: nested file not found", c.what)
+		}
+	}
+
+	r, err := NewReaderFromRCCWithOptions(bytes.NewReader(build(CompressionNone, map[string][]byte{"inner.rcc": inner})), &ReaderOptions{MaxNestedDepth: 1})
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+	e, err := r.Stat("inner.rcc", CountryAnyCountry, LanguageC)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	nr, err := e.OpenRCC()
+	if err != nil {
+		t.Fatalf("open nested rcc: %v", err)
+	}
+	defer nr.Close()
+	if nr.Parent().Path() != "inner.rcc" || nr.NestedDepth() != 1 {
+		t.Errorf("incorrect provenance")
+	}
+	ne, err := nr.Stat("deep.rcc", CountryAnyCountry, LanguageC)
+	if err != nil {
+		t.Fatalf("stat nested: %v", err)
+	}
+	if _, err := ne.OpenRCC(); !errors.Is(err, ErrMaxNestedDepth) {
+		t.Errorf("expected ErrMaxNestedDepth, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package qrc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PythonStyle is the Qt binding used by Python resource modules.
+type PythonStyle int
+
+const (
+	PythonPyQt5   PythonStyle = iota // like pyrcc5
+	PythonPyQt6                      // like pyside6-rcc, but for PyQt6
+	PythonPySide6                    // like pyside6-rcc
+)
+
+func (s PythonStyle) String() string {
+	switch s {
+	case PythonPyQt5:
+		return "PyQt5"
+	case PythonPyQt6:
+		return "PyQt6"
+	case PythonPySide6:
+		return "PySide6"
+	}
+	return fmt.Sprintf("PythonStyle(%d)", int(s))
+}
+
+// PythonOptions controls the output of Writer.WritePython.
+type PythonOptions struct {
+	Style PythonStyle
+}
+
+// WritePython writes the tree as a Python module which registers the resources
+// when imported, and defines the qInitResources and qCleanupResources
+// functions. For PythonPyQt5, if the format version is 2, a version 1 tree is
+// also included for Qt < 5.8 like pyrcc5.
+func (w *Writer) WritePython(out io.Writer, formatVersion int, opt PythonOptions) error {
+	l, err := w.layout(formatVersion)
+	if err != nil {
+		return err
+	}
+
+	var l1 *writerLayout
+	if opt.Style == PythonPyQt5 && formatVersion == 2 {
+		if l1, err = w.layout(1); err != nil {
+			return err
+		}
+	}
+
+	var module string
+	switch opt.Style {
+	case PythonPyQt5, PythonPyQt6, PythonPySide6:
+		module = opt.Style.String()
+	default:
+		return fmt.Errorf("unknown python style %s", opt.Style)
+	}
+
+	c := &codeWriter{w: bufio.NewWriter(out), python: true}
+
+	c.str("# -*- coding: utf-8 -*-\n\n")
+	c.str("# Resource object code\n")
+	c.str("#\n")
+	c.str("# Created by: github.com/pgaskin/qrc\n")
+	c.str("#\n")
+	c.str("# WARNING! All changes made in this file will be lost!\n\n")
+	c.str("from " + module + " import QtCore\n\n")
+
+	c.str("qt_resource_data = b\"\\\n")
+	c.data(l)
+	c.str("\"\n\n")
+
+	c.str("qt_resource_name = b\"\\\n")
+	c.names(l)
+	c.str("\"\n\n")
+
+	if l1 != nil {
+		c.str("qt_resource_struct_v1 = b\"\\\n")
+		c.tree(l1)
+		c.str("\"\n\n")
+
+		c.str("qt_resource_struct_v2 = b\"\\\n")
+		c.tree(l)
+		c.str("\"\n\n")
+
+		c.str("qt_version = [int(v) for v in QtCore.qVersion().split('.')]\n")
+		c.str("if qt_version < [5, 8, 0]:\n")
+		c.str("    rcc_version = 1\n")
+		c.str("    qt_resource_struct = qt_resource_struct_v1\n")
+		c.str("else:\n")
+		c.str("    rcc_version = 2\n")
+		c.str("    qt_resource_struct = qt_resource_struct_v2\n\n")
+	} else {
+		c.str("qt_resource_struct = b\"\\\n")
+		c.tree(l)
+		c.str("\"\n\n")
+
+		c.str("rcc_version = " + strconv.Itoa(formatVersion) + "\n\n")
+	}
+
+	c.str("def qInitResources():\n")
+	c.str("    QtCore.qRegisterResourceData(rcc_version, qt_resource_struct, qt_resource_name, qt_resource_data)\n\n")
+	c.str("def qCleanupResources():\n")
+	c.str("    QtCore.qUnregisterResourceData(rcc_version, qt_resource_struct, qt_resource_name, qt_resource_data)\n\n")
+	c.str("qInitResources()\n")
+
+	if err := c.w.Flush(); err != nil {
+		return fmt.Errorf("write python: %w", err)
+	}
+	return nil
+}
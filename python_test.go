@@ -0,0 +1,77 @@
+package qrc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWritePython(t *testing.T) {
+	for _, c := range []struct {
+		fixture string
+		format  int
+		opt     PythonOptions
+	}{
+		{"testdata/qrc_test_pyqt5.py", 2, PythonOptions{Style: PythonPyQt5}},
+		{"testdata/qrc_test_pyside6.py", 1, PythonOptions{Style: PythonPySide6}},
+	} {
+		var buf bytes.Buffer
+		if err := testWriter(t).WritePython(&buf, c.format, c.opt); err != nil {
+			t.Fatalf("%s: write python: %v", c.fixture, err)
+		}
+		exp, err := ioutil.ReadFile(c.fixture)
+		if err != nil {
+			t.Fatalf("%s: read fixture: %v", c.fixture, err)
+		}
+		if !bytes.Equal(buf.Bytes(), exp) {
+			t.Errorf("%s: output doesn't match fixture:\n%s", c.fixture, buf.String())
+		}
+
+		// rebuild a RCC file from the byte literals and read it
+		lit := func(name string) []byte {
+			m := regexp.MustCompile(`(?m)^` + name + ` = b"((?:[^"\\]|\\.|\\\n)*)"$`).FindStringSubmatch(buf.String())
+			if m == nil {
+				t.Fatalf("%s: missing %s", c.fixture, name)
+			}
+			b, err := hex.DecodeString(strings.NewReplacer("\\\n", "", "\\x", "").Replace(m[1]))
+			if err != nil {
+				t.Fatalf("%s: decode %s: %v", c.fixture, name, err)
+			}
+			return b
+		}
+		data, names := lit("qt_resource_data"), lit("qt_resource_name")
+		for v, name := range map[int]string{
+			1: "qt_resource_struct_v1",
+			2: "qt_resource_struct_v2",
+			3: "qt_resource_struct",
+		} {
+			if (c.format == 2 && c.opt.Style == PythonPyQt5) != (v != 3) {
+				continue
+			}
+			format := v
+			if v == 3 {
+				format = c.format
+			}
+			tree := lit(name)
+
+			var rcc []byte
+			rcc = append(rcc, RCCHeaderMagic[:]...)
+			rcc = appendUint32(rcc, uint32(format))
+			rcc = appendUint32(rcc, uint32(20+len(data)+len(names)))
+			rcc = appendUint32(rcc, uint32(20))
+			rcc = appendUint32(rcc, uint32(20+len(data)))
+			rcc = append(rcc, data...)
+			rcc = append(rcc, names...)
+			rcc = append(rcc, tree...)
+
+			r, err := NewReaderFromRCC(bytes.NewReader(rcc))
+			if err != nil {
+				t.Fatalf("%s: %s: read rcc: %v", c.fixture, name, err)
+			}
+			testFilesEqual(t, r, testFiles(t, testReader(t, testWriter(t), 2)))
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package qrc
 
 import (
-	"compress/zlib"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -11,8 +10,6 @@ import (
 	"time"
 	"unicode/utf16"
 	"unicode/utf8"
-
-	"github.com/klauspost/compress/zstd"
 )
 
 //go:generate go run locale_generate.go
@@ -58,60 +55,91 @@ func nodeSize(format int) int64 {
 // ParseNode reads a Qt resource tree node from the provided reader. If an error
 // occurs, any number of bytes may have been read from the reader.
 func ParseNode(r io.Reader, format int) (*Node, error) {
-	var n Node
-	n.Format = format
-
 	if format > 3 {
-		return nil, fmt.Errorf("unsupported qrc version %d", format)
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedFormat, format)
 	}
 
-	if x := binary.Size(uint16(0)); binary.Size(n.Language) != x || binary.Size(n.Country) != x || binary.Size(n.Flags) != x {
-		panic("wrong enum type size")
+	var buf [22]byte
+	b := buf[:nodeSize(format)]
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("read node: %w", err)
 	}
 
-	lr := io.LimitReader(r, nodeSize(n.Format)).(*io.LimitedReader)
+	n := &Node{Format: format}
+	if err := n.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
 
-	if err := binary.Read(lr, binary.BigEndian, &n.NameOffset); err != nil {
-		return nil, fmt.Errorf("read name offset: %w", err)
+// UnmarshalBinary decodes a node in the format version specified by the Format
+// field. It is the inverse of MarshalBinary.
+func (n *Node) UnmarshalBinary(b []byte) error {
+	format := n.Format
+	if format > 3 {
+		return fmt.Errorf("%w %d", ErrUnsupportedFormat, format)
+	}
+	if int64(len(b)) != nodeSize(format) {
+		return fmt.Errorf("node must be %d bytes, got %d", nodeSize(format), len(b))
 	}
 
-	if err := binary.Read(lr, binary.BigEndian, &n.Flags); err != nil {
-		return nil, fmt.Errorf("read flags: %w", err)
+	*n = Node{
+		NameOffset: binary.BigEndian.Uint32(b[0:]),
+		Flags:      NodeFlag(binary.BigEndian.Uint16(b[4:])),
+		Format:     format,
 	}
 	if err := n.Flags.Valid(); err != nil {
-		return nil, fmt.Errorf("read flags: %w", err)
+		return fmt.Errorf("read flags: %w", err)
 	}
 
 	if n.IsDir() {
-		if err := binary.Read(lr, binary.BigEndian, &n.ChildCount); err != nil {
-			return nil, fmt.Errorf("read dir child count: %w", err)
-		}
-		if err := binary.Read(lr, binary.BigEndian, &n.ChildOffset); err != nil {
-			return nil, fmt.Errorf("read dir child offset: %w", err)
-		}
+		n.ChildCount = binary.BigEndian.Uint32(b[6:])
+		n.ChildOffset = binary.BigEndian.Uint32(b[10:])
 	} else {
-		if err := binary.Read(lr, binary.BigEndian, &n.Country); err != nil {
-			return nil, fmt.Errorf("read file country qualifier: %w", err)
-		}
-		if err := binary.Read(lr, binary.BigEndian, &n.Language); err != nil {
-			return nil, fmt.Errorf("read file country qualifier: %w", err)
-		}
-		if err := binary.Read(lr, binary.BigEndian, &n.DataOffset); err != nil {
-			return nil, fmt.Errorf("read file data offset: %w", err)
-		}
+		n.Country = Country(binary.BigEndian.Uint16(b[6:]))
+		n.Language = Language(binary.BigEndian.Uint16(b[8:]))
+		n.DataOffset = binary.BigEndian.Uint32(b[10:])
 	}
 
-	if n.Format >= 2 {
-		if err := binary.Read(lr, binary.BigEndian, &n.Modified); err != nil {
-			return nil, fmt.Errorf("read mod time: %w", err)
-		}
+	if format >= 2 {
+		n.Modified = binary.BigEndian.Uint64(b[14:])
 	}
 
-	if lr.N != 0 {
-		return nil, fmt.Errorf("bug: should have read %d bytes more", lr.N)
+	return nil
+}
+
+// MarshalBinary encodes the node in the format version specified by the Format
+// field (14 bytes, or 22 bytes for format >= 2).
+func (n Node) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(make([]byte, 0, nodeSize(n.Format)))
+}
+
+// AppendBinary is like MarshalBinary, but appends the encoded node to b.
+func (n Node) AppendBinary(b []byte) ([]byte, error) {
+	if n.Format > 3 {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedFormat, n.Format)
+	}
+	if err := n.Flags.Valid(); err != nil {
+		return nil, err
+	}
+	if n.Flags.Has(NodeFlagCompressedZstd) && n.Format < 3 {
+		return nil, fmt.Errorf("%w: CompressedZstd requires format version 3", ErrInvalidFlags)
 	}
 
-	return &n, nil
+	b = appendUint32(b, n.NameOffset)
+	b = appendUint16(b, uint16(n.Flags))
+	if n.IsDir() {
+		b = appendUint32(b, n.ChildCount)
+		b = appendUint32(b, n.ChildOffset)
+	} else {
+		b = appendUint16(b, uint16(n.Country))
+		b = appendUint16(b, uint16(n.Language))
+		b = appendUint32(b, n.DataOffset)
+	}
+	if n.Format >= 2 {
+		b = appendUint64(b, n.Modified)
+	}
+	return b, nil
 }
 
 // IsDir returns true if the tree node represents a directory.
@@ -130,47 +158,99 @@ func (n Node) ModTime() time.Time {
 
 // Name reads the name of the file.
 func (n Node) Name(names io.ReaderAt) (string, error) {
-	var length uint16
-	if err := binary.Read(io.NewSectionReader(names, int64(n.NameOffset), 2), binary.BigEndian, &length); err != nil {
+	return readName(names, n.NameOffset, -1)
+}
+
+// readName reads a name from the names table. If max is non-negative, names
+// longer than max UTF-16 code units return ErrMaxNameLength.
+func readName(names io.ReaderAt, offset uint32, max int) (string, error) {
+	var hdr [6]byte // uint16 length, uint32 hash
+	if n, err := names.ReadAt(hdr[:], int64(offset)); n != len(hdr) {
 		var extra string
 		if err == io.EOF {
 			extra = " (maybe your offsets are incorrect?)"
 		}
-		return "", fmt.Errorf("read length from names at %#x%s: %w", n.NameOffset, extra, err)
+		return "", fmt.Errorf("read length and hash from names at %#x%s: %w", offset, extra, err)
+	}
+
+	length := binary.BigEndian.Uint16(hdr[:])
+	if max >= 0 && int(length) > max {
+		return "", fmt.Errorf("read name at %#x: length %d: %w (%d)", offset, length, ErrMaxNameLength, max)
 	}
 
-	var hash uint32
-	if err := binary.Read(io.NewSectionReader(names, int64(n.NameOffset+2), 4), binary.BigEndian, &hash); err != nil {
-		return "", fmt.Errorf("read hash from names at %#x: %w", n.NameOffset+2, err)
+	buf := make([]byte, int(length)*2)
+	if n, err := names.ReadAt(buf, int64(offset)+6); n != len(buf) {
+		return "", fmt.Errorf("read utf16 data from names at %#x (len=%d): %w", int64(offset)+6, len(buf), err)
 	}
 
-	var name string
-	buf := make([]uint16, length)
-	if err := binary.Read(io.NewSectionReader(names, int64(n.NameOffset+2+4), int64(length*2)), binary.BigEndian, &buf); err != nil {
-		return "", fmt.Errorf("read utf16 data from names at %#x (len=%d): %w", n.NameOffset+2+4, length*2, err)
+	// fast path for ascii names
+	ascii := true
+	for i := 0; i < len(buf); i += 2 {
+		if buf[i] != 0 || buf[i+1] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		for i := 0; i < int(length); i++ {
+			buf[i] = buf[i*2+1]
+		}
+		return string(buf[:length]), nil
+	}
+
+	u := make([]uint16, length)
+	for i := range u {
+		u[i] = binary.BigEndian.Uint16(buf[i*2:])
 	}
-	name = string(utf16.Decode(buf))
+	name := string(utf16.Decode(u))
 	if !utf8.ValidString(name) {
-		return "", fmt.Errorf("name is likely incorrect, is invalid utf8 (%q)", name) // note: may be too strict
+		return "", fmt.Errorf("%w: name is likely incorrect, is invalid utf8 (%q)", ErrInvalidName, name) // note: may be too strict
 	}
 	return name, nil
 }
 
+// AppendName appends an entry for the names table (the uint16 length, the
+// uint32 name hash, and the UTF-16 name) to b.
+func AppendName(b []byte, name string) ([]byte, error) {
+	u := utf16.Encode([]rune(name))
+	if len(u) > 0xFFFF {
+		return nil, fmt.Errorf("name %q too long", name)
+	}
+	b = appendUint16(b, uint16(len(u)))
+	b = appendUint32(b, qtHash(name))
+	for _, c := range u {
+		b = appendUint16(b, c)
+	}
+	return b, nil
+}
+
 // Children gets the children of the tree node. If it is not a directory, an
 // error is returned.
 func (n Node) Children(tree io.ReaderAt) ([]*Node, error) {
 	if !n.IsDir() {
-		return nil, fmt.Errorf("is a file, not a directory")
-	}
-
-	c := make([]*Node, int(n.ChildCount))
-	r := io.NewSectionReader(tree, n.dirTreeOffset(), n.dirSize())
-	for i := range c {
-		v, err := ParseNode(r, n.Format)
-		if err != nil {
-			return nil, fmt.Errorf("parse child (i=%d): %w", i, err)
+		return nil, ErrNotDir
+	}
+
+	// read the children in chunks so a bogus child count doesn't cause a huge
+	// allocation
+	const chunk = 4096
+	sz := nodeSize(n.Format)
+	c := make([]*Node, 0, minInt(int(n.ChildCount), chunk))
+	buf := make([]byte, int64(cap(c))*sz)
+	for i := 0; i < int(n.ChildCount); i += chunk {
+		cn := minInt(int(n.ChildCount)-i, chunk)
+		cb := buf[:int64(cn)*sz]
+		if k, err := tree.ReadAt(cb, n.dirTreeOffset()+int64(i)*sz); k != len(cb) {
+			return nil, fmt.Errorf("read children %d-%d: %w", i, i+cn-1, err)
+		}
+		ns := make([]Node, cn)
+		for j := range ns {
+			ns[j].Format = n.Format
+			if err := ns[j].UnmarshalBinary(cb[int64(j)*sz : int64(j+1)*sz]); err != nil {
+				return nil, fmt.Errorf("parse child (i=%d): %w", i+j, err)
+			}
+			c = append(c, &ns[j])
 		}
-		c[i] = v
 	}
 	return c, nil
 }
@@ -178,14 +258,15 @@ func (n Node) Children(tree io.ReaderAt) ([]*Node, error) {
 // Data opens a reader for the original content of the file, and also returns
 // the offset/size (relative to the data reader) of the corresponding data in
 // the resource (this may be smaller than the file contents if the data was
-// compressed). If the entry is a directory, an error is returned.
+// compressed). If the entry is a directory, an error is returned. The reader
+// must be closed to release the decompressor, and can't be used afterwards.
 func (n Node) Data(data io.ReaderAt) (rc io.ReadCloser, fileOff int64, fileSz int64, err error) {
 	if n.IsDir() {
-		return nil, 0, 0, fmt.Errorf("is a directory, not a file")
+		return nil, 0, 0, ErrIsDir
 	}
 
 	if err := n.Flags.Valid(); err != nil {
-		return nil, 0, 0, fmt.Errorf("invalid flags: %w", err)
+		return nil, 0, 0, err
 	}
 
 	fileOff = n.fileDataOffset()
@@ -205,23 +286,49 @@ func (n Node) Data(data io.ReaderAt) (rc io.ReadCloser, fileOff int64, fileSz in
 		}
 		fileOff += 4
 
-		zr, err := zlib.NewReader(r)
+		zr, err := newZlibReader(r)
 		if err != nil {
 			return nil, 0, 0, fmt.Errorf("open zlib reader: %w", err)
 		}
 		rc = zr
 	case n.Flags.Has(NodeFlagCompressedZstd):
-		zr, err := zstd.NewReader(r)
+		zr, err := newZstdReader(r)
 		if err != nil {
 			return nil, 0, 0, fmt.Errorf("open zstd reader: %w", err)
 		}
-		rc = ioutil.NopCloser(zr)
+		rc = zr
 	default:
 		rc = ioutil.NopCloser(r)
 	}
 	return rc, fileOff, fileSz, nil
 }
 
+// AppendData appends an entry for the data table (the uint32 size followed by
+// the data as stored) to b.
+func AppendData(b []byte, data []byte) ([]byte, error) {
+	if uint64(len(data)) > 0xFFFFFFFF {
+		return nil, fmt.Errorf("data too large")
+	}
+	b = appendUint32(b, uint32(len(data)))
+	b = append(b, data...)
+	return b, nil
+}
+
+// AppendZlibData is like AppendData, but adds the qCompress header with the
+// uncompressed size before the zlib data (for use with NodeFlagCompressed).
+func AppendZlibData(b []byte, zdata []byte, size int) ([]byte, error) {
+	if uint64(len(zdata))+4 > 0xFFFFFFFF {
+		return nil, fmt.Errorf("data too large")
+	}
+	if size < 0 || uint64(size) > 0xFFFFFFFF {
+		return nil, fmt.Errorf("uncompressed size %d out of range", size)
+	}
+	b = appendUint32(b, uint32(len(zdata))+4)
+	b = appendUint32(b, uint32(size))
+	b = append(b, zdata...)
+	return b, nil
+}
+
 func (n Node) dirTreeOffset() int64 {
 	if !n.IsDir() {
 		panic("dirTreeOffset called on non-dir node")
@@ -286,10 +393,10 @@ func (f NodeFlag) Has(v NodeFlag) bool {
 // format version.
 func (f NodeFlag) Valid() error {
 	if r := f.remainder(); r != 0 {
-		return fmt.Errorf("flag contains unknown bits %#b", r)
+		return fmt.Errorf("%w: flag contains unknown bits %#b", ErrInvalidFlags, r)
 	}
 	if f.Has(NodeFlagCompressed) && f.Has(NodeFlagCompressedZstd) {
-		return fmt.Errorf("flag cannot be Compressed and CompressedZstd at the same time")
+		return fmt.Errorf("%w: flag cannot be Compressed and CompressedZstd at the same time", ErrInvalidFlags)
 	}
 	return nil
 }
@@ -297,3 +404,22 @@ func (f NodeFlag) Valid() error {
 func (f NodeFlag) remainder() NodeFlag {
 	return f &^ (NodeFlagNone | NodeFlagCompressed | NodeFlagDirectory | NodeFlagCompressedZstd)
 }
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(v>>32)), uint32(v))
+}
@@ -1,6 +1,13 @@
 package qrc
 
-import "testing"
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
 
 // TODO
 
@@ -14,3 +21,151 @@ func TestNodeFlag(t *testing.T) {
 	// TODO: test validation for single flag
 	// TODO: test validation for a few flags
 }
+
+func TestNodeBinary(t *testing.T) {
+	for _, n := range []Node{
+		{NameOffset: 0x01020304, Flags: NodeFlagDirectory, ChildCount: 5, ChildOffset: 6, Format: 1},
+		{NameOffset: 0x01020304, Flags: NodeFlagDirectory, ChildCount: 5, ChildOffset: 6, Modified: 1600000000000, Format: 2},
+		{NameOffset: 7, Flags: NodeFlagCompressed, Country: CountryCanada, Language: LanguageFrench, DataOffset: 0xFFFFFFFF, Format: 1},
+		{NameOffset: 7, Flags: NodeFlagNone, Country: CountryCanada, Language: LanguageFrench, DataOffset: 8, Modified: 1, Format: 2},
+		{NameOffset: 7, Flags: NodeFlagCompressedZstd, DataOffset: 8, Modified: 1, Format: 3},
+	} {
+		b, err := n.MarshalBinary()
+		if err != nil {
+			t.Errorf("%+v: marshal: %v", n, err)
+			continue
+		}
+		if len(b) != int(nodeSize(n.Format)) {
+			t.Errorf("%+v: expected %d bytes, got %d", n, nodeSize(n.Format), len(b))
+		}
+		if x, err := n.AppendBinary([]byte{0xFF}); err != nil || !bytes.Equal(x[1:], b) || x[0] != 0xFF {
+			t.Errorf("%+v: append doesn't match marshal", n)
+		}
+		p, err := ParseNode(bytes.NewReader(b), n.Format)
+		if err != nil {
+			t.Errorf("%+v: parse: %v", n, err)
+		} else if *p != n {
+			t.Errorf("%+v: round-trip mismatch: %+v", n, *p)
+		}
+		u := Node{Format: n.Format}
+		if err := u.UnmarshalBinary(b); err != nil {
+			t.Errorf("%+v: unmarshal: %v", n, err)
+		} else if u != n {
+			t.Errorf("%+v: round-trip mismatch: %+v", n, u)
+		}
+	}
+	for _, n := range []Node{
+		{Flags: NodeFlagCompressed | NodeFlagCompressedZstd, Format: 3},
+		{Flags: NodeFlagCompressedZstd, Format: 2},
+		{Flags: 1 << 8, Format: 2},
+		{Format: 4},
+	} {
+		if _, err := n.MarshalBinary(); err == nil {
+			t.Errorf("%+v: expected error", n)
+		}
+	}
+}
+
+func TestAppendName(t *testing.T) {
+	var b []byte
+	var offsets []uint32
+	names := []string{"", "a.txt", "main.qml", "日本語.txt", "\U0001F600"}
+	for _, n := range names {
+		offsets = append(offsets, uint32(len(b)))
+		var err error
+		if b, err = AppendName(b, n); err != nil {
+			t.Fatalf("%q: append: %v", n, err)
+		}
+	}
+	for i, n := range names {
+		if x, err := (Node{NameOffset: offsets[i]}).Name(bytes.NewReader(b)); err != nil {
+			t.Errorf("%q: read: %v", n, err)
+		} else if x != n {
+			t.Errorf("%q: round-trip mismatch: %q", n, x)
+		}
+		if h := binary.BigEndian.Uint32(b[offsets[i]+2:]); h != qtHash(n) {
+			t.Errorf("%q: incorrect hash %#x", n, h)
+		}
+	}
+	if _, err := AppendName(nil, strings.Repeat("a", 0x10000)); err == nil {
+		t.Errorf("expected error for long name")
+	}
+}
+
+func TestAppendData(t *testing.T) {
+	data := bytes.Repeat([]byte("hello world "), 10)
+
+	var zb bytes.Buffer
+	zw := zlib.NewWriter(&zb)
+	zw.Write(data)
+	zw.Close()
+
+	b, err := AppendData(nil, data)
+	if err != nil {
+		t.Fatalf("append data: %v", err)
+	}
+	zoff := len(b)
+	if b, err = AppendZlibData(b, zb.Bytes(), len(data)); err != nil {
+		t.Fatalf("append zlib data: %v", err)
+	}
+
+	for _, n := range []Node{
+		{Flags: NodeFlagNone, DataOffset: 0},
+		{Flags: NodeFlagCompressed, DataOffset: uint32(zoff)},
+	} {
+		rc, _, _, err := n.Data(bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("%s: open: %v", n.Flags, err)
+		}
+		x, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("%s: read: %v", n.Flags, err)
+		}
+		if !bytes.Equal(x, data) {
+			t.Errorf("%s: round-trip mismatch", n.Flags)
+		}
+	}
+	if sz := binary.BigEndian.Uint32(b[zoff+4:]); sz != uint32(len(data)) {
+		t.Errorf("incorrect qCompress header %d", sz)
+	}
+	if _, err := AppendZlibData(nil, nil, -1); err == nil {
+		t.Errorf("expected error for negative size")
+	}
+}
+
+func TestNodeChildren(t *testing.T) {
+	const count = 10000
+	for _, format := range []int{1, 2} {
+		tree, err := Node{Flags: NodeFlagDirectory, ChildCount: count, ChildOffset: 1, Format: format}.MarshalBinary()
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		for i := 0; i < count; i++ {
+			if tree, err = (Node{NameOffset: uint32(i), DataOffset: uint32(i * 2), Format: format}).AppendBinary(tree); err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+		}
+
+		root, err := ParseNode(bytes.NewReader(tree), format)
+		if err != nil {
+			t.Fatalf("parse root: %v", err)
+		}
+		c, err := root.Children(bytes.NewReader(tree))
+		if err != nil {
+			t.Fatalf("format %d: parse children: %v", format, err)
+		}
+		if len(c) != count {
+			t.Fatalf("format %d: expected %d children, got %d", format, count, len(c))
+		}
+		for i, n := range c {
+			if n.NameOffset != uint32(i) || n.DataOffset != uint32(i*2) || n.Format != format {
+				t.Fatalf("format %d: incorrect child %d: %+v", format, i, *n)
+			}
+		}
+
+		if _, err := root.Children(bytes.NewReader(tree[:len(tree)-1])); err == nil {
+			t.Errorf("format %d: expected error for truncated tree", format)
+		}
+	}
+}
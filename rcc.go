@@ -34,7 +34,7 @@ func ParseRCCHeader(r io.Reader) (*RCCHeader, error) {
 	}
 
 	if h.Magic != RCCHeaderMagic {
-		return nil, fmt.Errorf("invalid magic %#v", h.Magic)
+		return nil, fmt.Errorf("%w %#v", ErrBadMagic, h.Magic)
 	}
 
 	if err := binary.Read(r, binary.BigEndian, &h.FormatVersion); err != nil {
@@ -60,8 +60,40 @@ func ParseRCCHeader(r io.Reader) (*RCCHeader, error) {
 	}
 
 	if h.FormatVersion > 3 {
-		return nil, fmt.Errorf("unsupported format version %d", &h.FormatVersion)
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedFormat, h.FormatVersion)
 	}
 
 	return &h, nil
 }
+
+// MarshalBinary encodes the header. OverallFlags is only included if
+// FormatVersion >= 3.
+func (h RCCHeader) MarshalBinary() ([]byte, error) {
+	return h.AppendBinary(make([]byte, 0, rccHeaderSize(int(h.FormatVersion))))
+}
+
+// AppendBinary is like MarshalBinary, but appends the encoded header to b.
+func (h RCCHeader) AppendBinary(b []byte) ([]byte, error) {
+	if h.Magic != RCCHeaderMagic {
+		return nil, fmt.Errorf("%w %#v", ErrBadMagic, h.Magic)
+	}
+	if h.FormatVersion > 3 {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedFormat, h.FormatVersion)
+	}
+	b = append(b, h.Magic[:]...)
+	b = appendUint32(b, uint32(h.FormatVersion))
+	b = appendUint32(b, uint32(h.TreeOffset))
+	b = appendUint32(b, uint32(h.DataOffset))
+	b = appendUint32(b, uint32(h.NamesOffset))
+	if h.FormatVersion >= 3 {
+		b = appendUint32(b, uint32(h.OverallFlags))
+	}
+	return b, nil
+}
+
+func rccHeaderSize(format int) int {
+	if format >= 3 {
+		return 4 * 6
+	}
+	return 4 * 5
+}
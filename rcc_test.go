@@ -1,6 +1,9 @@
 package qrc
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+)
 
 func TestRCC(t *testing.T) {
 	// TODO: test correct RCC header format<3
@@ -10,3 +13,34 @@ func TestRCC(t *testing.T) {
 	// TODO: test short RCC header format=3
 	// TODO: test getting reader, but not actually reading it
 }
+
+func TestRCCHeaderBinary(t *testing.T) {
+	for _, h := range []RCCHeader{
+		{Magic: RCCHeaderMagic, FormatVersion: 1, TreeOffset: 1, DataOffset: 2, NamesOffset: 3},
+		{Magic: RCCHeaderMagic, FormatVersion: 2, TreeOffset: 1, DataOffset: 2, NamesOffset: 3},
+		{Magic: RCCHeaderMagic, FormatVersion: 3, TreeOffset: 1, DataOffset: 2, NamesOffset: 3, OverallFlags: int32(NodeFlagCompressedZstd)},
+	} {
+		b, err := h.MarshalBinary()
+		if err != nil {
+			t.Errorf("%+v: marshal: %v", h, err)
+			continue
+		}
+		if len(b) != rccHeaderSize(int(h.FormatVersion)) {
+			t.Errorf("%+v: expected %d bytes, got %d", h, rccHeaderSize(int(h.FormatVersion)), len(b))
+		}
+		p, err := ParseRCCHeader(bytes.NewReader(b))
+		if err != nil {
+			t.Errorf("%+v: parse: %v", h, err)
+		} else if *p != h {
+			t.Errorf("%+v: round-trip mismatch: %+v", h, *p)
+		}
+	}
+	for _, h := range []RCCHeader{
+		{FormatVersion: 1},
+		{Magic: RCCHeaderMagic, FormatVersion: 4},
+	} {
+		if _, err := h.MarshalBinary(); err == nil {
+			t.Errorf("%+v: expected error", h)
+		}
+	}
+}
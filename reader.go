@@ -2,12 +2,12 @@ package qrc
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"math"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,14 +19,150 @@ type Reader struct {
 	treeOffset  int64
 	dataOffset  int64
 	namesOffset int64
+	treeSize    int64 // -1 if unbounded
+	dataSize    int64 // -1 if unbounded
+	namesSize   int64 // -1 if unbounded
+	treeRegion  *regionReader
+	dataRegion  *regionReader
+	namesRegion *regionReader
 	root        *Node
+	index       *pathIndex // nil if disabled
+	opt         ReaderOptions
+	limits      *readerLimits // shared with nested readers
+	nested      int           // nested RCC depth
+	parent      *ReaderEntry  // entry the nested RCC was opened from, or nil
+	base        int64         // absolute offset of the reader, or -1 if the parent is compressed
+	release     func() error  // releases a nested RCC's temporary file, or nil
+}
+
+// ReaderOptions limits the resources used by a Reader. Zero values use the
+// default limits (which are suitable for untrusted input), and negative values
+// disable the limit.
+type ReaderOptions struct {
+	// MaxDepth is the maximum depth of an entry below the root, i.e. the number
+	// of path components (default 128). If exceeded, ErrMaxDepth is returned.
+	MaxDepth int
+
+	// MaxNodes is the maximum number of nodes in a single directory or visited
+	// during a single Walk, including nested RCC files (default 4194304). If
+	// exceeded, ErrMaxNodes is returned.
+	MaxNodes int
+
+	// MaxFileSize is the maximum decompressed size of a single compressed file
+	// (default 1 GiB). If exceeded, ErrMaxFileSize is returned while reading.
+	// StreamReader also uses it to limit the size of the regions it buffers.
+	MaxFileSize int64
+
+	// MaxTotalSize is the maximum total decompressed size of all compressed
+	// files read from the Reader and any nested RCC files opened from it
+	// (default 16 GiB). If exceeded, ErrMaxTotalSize is returned while reading.
+	MaxTotalSize int64
+
+	// MaxNestedDepth is the maximum depth of nested RCC files opened by Walk
+	// (default 8). If exceeded, ErrMaxNestedDepth is passed to the WalkFunc
+	// for the nested RCC file.
+	MaxNestedDepth int
+
+	// MaxNameLength is the maximum length of a name in UTF-16 code units
+	// (default 1024). If exceeded, ErrMaxNameLength is returned.
+	MaxNameLength int
+
+	// TreeSize, DataSize, and NamesSize are the sizes of the regions starting
+	// at the tree, data, and names offsets. If zero, the size is inferred from
+	// the offset of the next region after it, or the size of the underlying
+	// reader (if it has a Size method or is a regular file). If negative, or if
+	// the size can't be inferred, the region is unbounded. If a read goes past
+	// the end of a region, an *OverrunError is returned.
+	TreeSize  int64
+	DataSize  int64
+	NamesSize int64
+
+	// Preload reads the entire tree and names regions into memory when
+	// creating the Reader, which is much faster for listing large trees from
+	// files. Regions with an unknown size are not preloaded.
+	Preload bool
+
+	// PreloadSize is the maximum size of a region to preload if the size was
+	// inferred rather than set explicitly (default 4 MiB), since the inferred
+	// size may include unrelated data (e.g. up to the end of an executable).
+	// Larger regions are read as needed instead.
+	PreloadSize int64
+
+	// IndexSize is the maximum number of entries in the directory listings
+	// cached for Lookup, Stat, and Open (default 65536). If negative, the
+	// index is disabled.
+	IndexSize int
+
+	// IndexEager fills the index when creating the Reader instead of as paths
+	// are looked up.
+	IndexEager bool
+
+	// SpillSize is the uncompressed size above which compressed nested RCC
+	// files and Archive members are decompressed into a temporary file in
+	// SpillDir (or the default directory for temporary files) instead of
+	// memory. If zero or negative, they are always decompressed into memory.
+	// Uncompressed ones are always read directly from the parent. StreamReader
+	// also uses it for the data region if it must be buffered, and defaults it
+	// to 4 MiB instead (set it to a negative value to disable spilling).
+	SpillSize int64
+	SpillDir  string
+
+	// NestedSniff makes Walk also treat files starting with RCCHeaderMagic
+	// (after decompression) as nested RCC files, not just ones with the .rcc
+	// extension.
+	NestedSniff bool
+
+	// NestedFunc, if set, is called by Walk for each file to decide whether
+	// to treat it as a nested RCC file instead of checking the extension and
+	// NestedSniff. ReaderEntry.IsRCC can be used to check the magic.
+	NestedFunc func(path string, entry *ReaderEntry) bool
+}
+
+// withDefaults returns a copy of the options with default values filled in.
+func (o *ReaderOptions) withDefaults() ReaderOptions {
+	var x ReaderOptions
+	if o != nil {
+		x = *o
+	}
+	if x.MaxDepth == 0 {
+		x.MaxDepth = 128
+	}
+	if x.MaxNodes == 0 {
+		x.MaxNodes = 1 << 22
+	}
+	if x.MaxFileSize == 0 {
+		x.MaxFileSize = 1 << 30
+	}
+	if x.MaxTotalSize == 0 {
+		x.MaxTotalSize = 1 << 34
+	}
+	if x.MaxNestedDepth == 0 {
+		x.MaxNestedDepth = 8
+	}
+	if x.MaxNameLength == 0 {
+		x.MaxNameLength = 1024
+	}
+	if x.IndexSize == 0 {
+		x.IndexSize = 65536
+	}
+	if x.PreloadSize == 0 {
+		x.PreloadSize = 4 << 20
+	}
+	return x
+}
+
+type readerLimits struct {
+	total int64 // atomic; must be first for alignment
 }
 
 // ReaderEntry is an entry read by a Reader.
 type ReaderEntry struct {
 	v string
+	p string // path, for errors
 	n *Node
+	i uint32 // node index
 	r *Reader
+	d int // depth below the root
 }
 
 // WalkFunc is the same as filepath.WalkFunc. The path is always separated with
@@ -37,30 +173,116 @@ type WalkFunc func(path string, entry *ReaderEntry, err error) error
 
 // NewReader initializes a reader with the provided version and offsets.
 func NewReader(r io.ReaderAt, formatVersion int, treeOffset, dataOffset, namesOffset int64) (*Reader, error) {
+	return NewReaderWithOptions(r, formatVersion, treeOffset, dataOffset, namesOffset, nil)
+}
+
+// NewReaderWithOptions is like NewReader, but with the provided options. If opt
+// is nil, the default limits are used.
+func NewReaderWithOptions(r io.ReaderAt, formatVersion int, treeOffset, dataOffset, namesOffset int64, opt *ReaderOptions) (*Reader, error) {
 	rd := &Reader{
 		format:      formatVersion,
 		reader:      r,
 		treeOffset:  treeOffset,
 		dataOffset:  dataOffset,
 		namesOffset: namesOffset,
+		opt:         opt.withDefaults(),
+		limits:      new(readerLimits),
+	}
+
+	for _, x := range []struct {
+		size   *int64
+		opt    int64
+		offset int64
+	}{
+		{&rd.treeSize, rd.opt.TreeSize, treeOffset},
+		{&rd.dataSize, rd.opt.DataSize, dataOffset},
+		{&rd.namesSize, rd.opt.NamesSize, namesOffset},
+	} {
+		switch {
+		case x.opt < 0:
+			*x.size = -1
+		case x.opt > 0:
+			*x.size = x.opt
+		default:
+			*x.size = regionSize(r, x.offset, treeOffset, dataOffset, namesOffset)
+		}
+	}
+
+	rd.treeRegion = &regionReader{r, "tree", treeOffset, rd.treeSize}
+	rd.dataRegion = &regionReader{r, "data", dataOffset, rd.dataSize}
+	rd.namesRegion = &regionReader{r, "names", namesOffset, rd.namesSize}
+
+	if rd.opt.Preload {
+		for _, x := range []struct {
+			rr       **regionReader
+			explicit bool
+		}{
+			{&rd.treeRegion, rd.opt.TreeSize > 0},
+			{&rd.namesRegion, rd.opt.NamesSize > 0},
+		} {
+			rr := *x.rr
+			if rr.size < 0 {
+				continue
+			}
+			if max := rd.opt.PreloadSize; !x.explicit && max >= 0 && rr.size > max {
+				continue
+			}
+			buf := make([]byte, rr.size)
+			if n, err := r.ReadAt(buf, rr.base); n != len(buf) {
+				return nil, fmt.Errorf("preload %s region: %w", rr.region, &Error{
+					Region: rr.region,
+					Offset: rr.base,
+					Err:    err,
+				})
+			}
+			*x.rr = &regionReader{bytes.NewReader(buf), rr.region, 0, rr.size}
+		}
 	}
 
-	n, err := ParseNode(rd.tree(), rd.format)
+	n, err := ParseNode(io.NewSectionReader(rd.tree(), 0, nodeSize(rd.format)), rd.format)
 	if err != nil {
-		return nil, fmt.Errorf("parse root node: %w", err)
+		return nil, fmt.Errorf("parse root node: %w", &Error{
+			Region: "tree",
+			Offset: treeOffset,
+			Err:    err,
+		})
 	}
 	rd.root = n
 
+	if rd.opt.IndexSize >= 0 {
+		rd.index = newPathIndex(rd.opt.IndexSize)
+		if rd.opt.IndexEager {
+			rd.buildIndex()
+		}
+	}
+
 	return rd, nil
 }
 
 // NewReaderFromRCC initializes a reader for the provided RCC file.
 func NewReaderFromRCC(r io.ReaderAt) (*Reader, error) {
-	h, err := ParseRCCHeader(io.NewSectionReader(r, 0, 1<<63-1))
+	return NewReaderFromRCCAt(r, 0, nil)
+}
+
+// NewReaderFromRCCWithOptions is like NewReaderFromRCC, but with the provided
+// options. If opt is nil, the default limits are used.
+func NewReaderFromRCCWithOptions(r io.ReaderAt, opt *ReaderOptions) (*Reader, error) {
+	return NewReaderFromRCCAt(r, 0, opt)
+}
+
+// NewReaderFromRCCAt is like NewReaderFromRCC, but for a RCC file starting at
+// base (e.g. one embedded in a larger file). Offsets (including the ones in
+// errors) are still relative to r.
+func NewReaderFromRCCAt(r io.ReaderAt, base int64, opt *ReaderOptions) (*Reader, error) {
+	h, err := ParseRCCHeader(io.NewSectionReader(r, base, 1<<63-1-base))
 	if err != nil {
-		return nil, fmt.Errorf("parse rcc header: %w", err)
+		return nil, fmt.Errorf("parse rcc header: %w", &Error{
+			Region: "header",
+			Offset: base,
+			Err:    err,
+		})
 	}
-	return NewReader(r, int(h.FormatVersion), int64(h.TreeOffset), int64(h.DataOffset), int64(h.NamesOffset))
+	return NewReaderWithOptions(r, int(h.FormatVersion), base+int64(h.TreeOffset), base+int64(h.DataOffset), base+int64(h.NamesOffset), opt)
 }
 
 // TODO: func NewReaderFromELF(f *elf.File) ([]*Reader, error); either find calls to qRegisterResourceData or use a heuristic
@@ -75,16 +297,23 @@ func (r *Reader) Children() ([]*ReaderEntry, error) {
 
 // Walk calls the provided WalkFunc for each entry in the tree, similarly to
 // filepath.Walk (including filepath.SkipDir). If rccRecurse is true, nested RCC
-// files are opened and treated as a directory.
+// files (see ReaderOptions.NestedSniff and ReaderOptions.NestedFunc) are opened
+// and treated as a directory. See Iter for an alternative without a callback.
 func (r *Reader) Walk(fn WalkFunc, rccRecurse bool) error {
-	return walk(fn, rccRecurse, "", &ReaderEntry{
+	var nodes int
+	return walk(fn, rccRecurse, &nodes, "", &ReaderEntry{
 		n: r.root,
 		r: r,
 	})
 }
 
 // walk is a recursive depth-first helper for Walk.
-func walk(fn WalkFunc, rccRecurse bool, path string, entry *ReaderEntry) error {
+func walk(fn WalkFunc, rccRecurse bool, nodes *int, path string, entry *ReaderEntry) error {
+	*nodes++
+	if max := entry.r.opt.MaxNodes; max >= 0 && *nodes > max {
+		return fmt.Errorf("walk %q: %w (%d)", path, ErrMaxNodes, max)
+	}
+
 	if entry.IsDir() {
 		// attempt to read the dir's children
 		c, err := entry.Children()
@@ -115,7 +344,7 @@ func walk(fn WalkFunc, rccRecurse bool, path string, entry *ReaderEntry) error {
 
 		// call fn for the dir's contents
 		for _, v := range c {
-			if err := walk(fn, rccRecurse, strings.TrimLeft(path+"/"+v.Name(), "/"), v); err != nil {
+			if err := walk(fn, rccRecurse, nodes, strings.TrimLeft(path+"/"+v.Name(), "/"), v); err != nil {
 				if err == filepath.SkipDir {
 					panic("filepath.SkipDir shouldn't have been returned from walk")
 				}
@@ -130,11 +359,10 @@ func walk(fn WalkFunc, rccRecurse bool, path string, entry *ReaderEntry) error {
 		return nil
 	}
 
-	// check whether to treat the file as a nested rcc dir
-	if rccRecurse && filepath.Ext(path) == ".rcc" {
-		// attempt to open the rcc
-		d, err := entry.Open()
-
+	// check whether to treat the file as a nested rcc dir and attempt to open
+	// it (this also checks the nesting limit)
+	if rccRecurse {
+		r, err := entry.OpenNestedRCC(path)
 		if err != nil {
 			// call fn for the rcc itself with the error
 			if err := fn(path, entry, fmt.Errorf("walk: open nested rcc %q: %w", path, err)); err != nil {
@@ -145,49 +373,24 @@ func walk(fn WalkFunc, rccRecurse bool, path string, entry *ReaderEntry) error {
 			}
 			return nil
 		}
-		defer d.Close()
-
-		// attempt to read the rcc
-		buf, err := ioutil.ReadAll(d)
-
-		if err != nil {
-			// call fn for the rcc itself with the error
-			if err := fn(path, entry, fmt.Errorf("walk: read nested rcc %q into memory: %w", path, err)); err != nil {
+		if r != nil {
+			defer r.Close()
+
+			// re-walk the opened rcc as a dir
+			if err := walk(fn, rccRecurse, nodes, path, &ReaderEntry{
+				v: entry.v,
+				p: entry.p,
+				n: r.root,
+				r: r,
+			}); err != nil {
 				if err == filepath.SkipDir {
-					return nil
+					panic("filepath.SkipDir shouldn't have been returned from walk")
 				}
-				return fmt.Errorf("walk %q: %w", path, err)
+				return fmt.Errorf("walk nested rcc %q: %w", path, err)
 			}
-			return nil
-		}
-
-		// attempt to parse the rcc
-		r, err := NewReaderFromRCC(bytes.NewReader(buf))
 
-		if err != nil {
-			// call fn for the rcc itself with the error
-			if err := fn(path, entry, fmt.Errorf("walk: parse nested rcc %q: %w", path, err)); err != nil {
-				if err == filepath.SkipDir {
-					return nil
-				}
-				return fmt.Errorf("walk %q: %w", path, err)
-			}
 			return nil
 		}
-
-		// re-walk the opened rcc as a dir
-		if err := walk(fn, rccRecurse, path, &ReaderEntry{
-			v: entry.v,
-			n: r.root,
-			r: r,
-		}); err != nil {
-			if err == filepath.SkipDir {
-				panic("filepath.SkipDir shouldn't have been returned from walk")
-			}
-			return fmt.Errorf("walk nested rcc %q: %w", path, err)
-		}
-
-		return nil
 	}
 
 	if err := fn(path, entry, nil); err != nil {
@@ -200,23 +403,84 @@ func walk(fn WalkFunc, rccRecurse bool, path string, entry *ReaderEntry) error {
 	return nil
 }
 
-func (r Reader) tree() *io.SectionReader {
-	return io.NewSectionReader(r.reader, r.treeOffset, math.MaxInt32)
+func (r Reader) tree() *regionReader {
+	return r.treeRegion
+}
+
+func (r Reader) data() *regionReader {
+	return r.dataRegion
 }
 
-func (r Reader) data() *io.SectionReader {
-	return io.NewSectionReader(r.reader, r.dataOffset, math.MaxInt32)
+func (r Reader) names() *regionReader {
+	return r.namesRegion
 }
 
-func (r Reader) names() *io.SectionReader {
-	return io.NewSectionReader(r.reader, r.namesOffset, math.MaxInt32)
+// name reads the name of a node, checking the length against the limit.
+func (r Reader) name(n *Node) (string, error) {
+	return readName(r.names(), n.NameOffset, r.opt.MaxNameLength)
+}
+
+// fileReader reads a file, limiting the size of decompressed data and wrapping
+// errors in an *Error.
+type fileReader struct {
+	rc       io.ReadCloser
+	n        int64 // remaining bytes, or negative if unlimited
+	max      int64
+	limits   *readerLimits
+	maxTotal int64
+	pos      int64  // uncompressed offset
+	hw       *int64 // offset up to which the output has already been counted in limits
+	errFn    func(error) error
+	err      error
+}
+
+func (f *fileReader) Read(p []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	if f.n >= 0 && int64(len(p)) > f.n+1 {
+		p = p[:f.n+1] // read an extra byte to check if the limit was exceeded
+	}
+	n, err := f.rc.Read(p)
+	if f.n >= 0 {
+		if int64(n) > f.n {
+			n, f.err = int(f.n), f.errFn(fmt.Errorf("%w (%d)", ErrMaxFileSize, f.max))
+		}
+		f.n -= int64(n)
+	}
+	if f.maxTotal >= 0 && f.err == nil {
+		// don't count output again after seeking backwards
+		start, end := f.pos, f.pos+int64(n)
+		if start < *f.hw {
+			start = *f.hw
+		}
+		if end > start {
+			*f.hw = end
+			if atomic.AddInt64(&f.limits.total, end-start) > f.maxTotal {
+				f.err = f.errFn(fmt.Errorf("%w (%d)", ErrMaxTotalSize, f.maxTotal))
+			}
+		}
+	}
+	f.pos += int64(n)
+	if f.err != nil {
+		return n, f.err
+	}
+	if err != nil && err != io.EOF {
+		f.err = f.errFn(err)
+		return n, f.err
+	}
+	return n, err
+}
+
+func (f *fileReader) Close() error {
+	return f.rc.Close()
 }
 
 func newReaderEntry(r *Reader, n *Node) (*ReaderEntry, error) {
 	var e ReaderEntry
 	e.r = r
 	e.n = n
-	if v, err := n.Name(r.names()); err != nil {
+	if v, err := r.name(n); err != nil {
 		return nil, err
 	} else {
 		e.v = v
@@ -229,6 +493,12 @@ func (e ReaderEntry) Name() string {
 	return e.v
 }
 
+// Path returns the path of the entry. For entries in nested RCC files opened by
+// Walk, it includes the path of the nested RCC file.
+func (e ReaderEntry) Path() string {
+	return e.p
+}
+
 // Constraints returns the country/language constraints for the file. A
 // directory can contain multiple files with the same name, but different constraints.
 func (e ReaderEntry) Constraints() (Country, Language) {
@@ -252,34 +522,81 @@ func (e ReaderEntry) Flags() NodeFlag {
 }
 
 // Children reads and returns the child entries. If the entry is not a
-// directory, an error is returned.
+// directory, an error wrapping ErrNotDir is returned. Errors are returned as
+// an *Error.
 func (e ReaderEntry) Children() ([]*ReaderEntry, error) {
+	if !e.IsDir() {
+		return nil, e.error("tree", e.r.treeOffset+int64(e.i)*nodeSize(e.r.format), ErrNotDir)
+	}
+	if max := e.r.opt.MaxDepth; max >= 0 && e.d+1 > max {
+		return nil, e.error("tree", e.r.treeOffset+int64(e.i)*nodeSize(e.r.format), fmt.Errorf("%w (%d)", ErrMaxDepth, max))
+	}
+	if max := e.r.opt.MaxNodes; max >= 0 && int64(e.n.ChildCount) > int64(max) {
+		return nil, e.error("tree", e.r.treeOffset+int64(e.i)*nodeSize(e.r.format), fmt.Errorf("%d children: %w (%d)", e.n.ChildCount, ErrMaxNodes, max))
+	}
+	if err := e.r.tree().check(e.n.dirTreeOffset(), e.n.dirSize()); err != nil {
+		return nil, e.error("tree", e.r.treeOffset+e.n.dirTreeOffset(), err)
+	}
+
 	n, err := e.n.Children(e.r.tree())
 	if err != nil {
-		return nil, err
+		return nil, e.error("tree", e.r.treeOffset+e.n.dirTreeOffset(), err)
 	}
 
 	x := make([]*ReaderEntry, len(n))
+	es := make([]ReaderEntry, len(n))
 	for i := range n {
-		v, err := n[i].Name(e.r.names())
+		v, err := e.r.name(n[i])
 		if err != nil {
-			return nil, fmt.Errorf("parse child %d: read name %w", i, err)
+			return nil, e.error("names", e.r.namesOffset+int64(n[i].NameOffset), fmt.Errorf("parse child %d: read name: %w", i, err))
 		}
-		x[i] = &ReaderEntry{
+		x[i] = &es[i]
+		es[i] = ReaderEntry{
 			v: v,
+			p: strings.TrimLeft(e.p+"/"+v, "/"),
 			n: n[i],
+			i: e.n.ChildOffset + uint32(i),
 			r: e.r,
+			d: e.d + 1,
 		}
 	}
 
 	return x, nil
 }
 
-// Open opens a reader for the contents of the entry. If the entry is a
-// directory, an error is returned.
-func (e ReaderEntry) Open() (io.ReadCloser, error) {
-	rc, _, _, err := e.n.Data(e.r.data())
-	return rc, err
+// limit wraps rc, which starts at the uncompressed offset off, with the
+// limits for the entry. Only the output after hw is counted for MaxTotalSize,
+// and hw is updated as it is read.
+func (e ReaderEntry) limit(rc io.ReadCloser, off int64, hw *int64, errFn func(error) error) *fileReader {
+	f := &fileReader{
+		rc:       rc,
+		n:        -1,
+		maxTotal: -1,
+		pos:      off,
+		hw:       hw,
+		errFn:    errFn,
+	}
+	if e.n.Flags&(NodeFlagCompressed|NodeFlagCompressedZstd) != 0 {
+		f.n = e.r.opt.MaxFileSize
+		if f.n >= 0 {
+			if f.n -= off; f.n < 0 {
+				f.n = 0
+			}
+		}
+		f.max = e.r.opt.MaxFileSize
+		f.limits = e.r.limits
+		f.maxTotal = e.r.opt.MaxTotalSize
+	}
+	return f
+}
+
+func (e ReaderEntry) error(region string, offset int64, err error) error {
+	return &Error{
+		Region: region,
+		Offset: offset,
+		Path:   e.p,
+		Err:    err,
+	}
 }
 
 // Offset returns the real offset of the entry's contents relative to the base
@@ -304,10 +621,110 @@ func (e ReaderEntry) Offset() int64 {
 // Reader. If the entry is a directory, the size is the total of all child tree
 // nodes (i.e. Offset() + Size() = end of last child). If the entry is a file, the
 // size is the size of the underlying data in the file. To get the uncompressed
-// size, Open() the entry and count the number of bytes read.
+// size, use UncompressedSize.
 func (e ReaderEntry) Size() (int64, error) {
 	if e.IsDir() {
 		return e.n.dirSize(), nil
 	}
-	return e.n.fileSize(e.r.data())
+	sz, err := e.n.fileSize(e.r.data())
+	if err != nil {
+		return 0, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), err)
+	}
+	return sz, nil
+}
+
+// Compression returns the compression algorithm used for the file's data. If
+// the entry is a directory, CompressionNone is returned.
+func (e ReaderEntry) Compression() Compression {
+	switch {
+	case e.IsDir():
+		return CompressionNone
+	case e.n.Flags.Has(NodeFlagCompressed):
+		return CompressionZlib
+	case e.n.Flags.Has(NodeFlagCompressedZstd):
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// CompressedSize returns the size of the file's data as stored, which is the
+// same as Size. If the entry is a directory, an error wrapping ErrIsDir is
+// returned. Errors are returned as an *Error.
+func (e ReaderEntry) CompressedSize() (int64, error) {
+	if e.IsDir() {
+		return 0, e.error("tree", e.r.treeOffset+int64(e.i)*nodeSize(e.r.format), ErrIsDir)
+	}
+	return e.Size()
+}
+
+// UncompressedSize returns the size of the file's contents. For zlib data, this
+// is the size from the qCompress header. For zstd data, this is the total
+// content size from the frame headers, or if it isn't present, the data is
+// decompressed to get the size. If the entry is a directory, an error wrapping
+// ErrIsDir is returned. Errors are returned as an *Error.
+func (e ReaderEntry) UncompressedSize() (int64, error) {
+	sz, err := e.CompressedSize()
+	if err != nil {
+		return 0, err
+	}
+	switch e.Compression() {
+	case CompressionZlib:
+		var buf [4]byte
+		if _, err := io.ReadFull(io.NewSectionReader(e.r.data(), e.n.fileDataOffset(), sz), buf[:]); err != nil {
+			return 0, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), fmt.Errorf("read qCompress original size header from zlib data: %w", err))
+		}
+		return int64(binary.BigEndian.Uint32(buf[:])), nil
+	case CompressionZstd:
+		if _, fcs, err := zstdFrames(io.NewSectionReader(e.r.data(), e.n.fileDataOffset(), sz), sz); err == nil && fcs >= 0 {
+			return fcs, nil
+		}
+		f, err := e.OpenFile()
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		return f.UncompressedSize()
+	}
+	return sz, nil
+}
+
+// Ratio returns the compressed size divided by the uncompressed size. If the
+// uncompressed size is zero, the ratio is 1. If the entry is a directory, an
+// error wrapping ErrIsDir is returned. Errors are returned as an *Error.
+func (e ReaderEntry) Ratio() (float64, error) {
+	csz, err := e.CompressedSize()
+	if err != nil {
+		return 0, err
+	}
+	usz, err := e.UncompressedSize()
+	if err != nil {
+		return 0, err
+	}
+	if usz == 0 {
+		return 1, nil
+	}
+	return float64(csz) / float64(usz), nil
+}
+
+// raw reads the underlying (possibly compressed) data for a file as-is,
+// including the qCompress header if present.
+func (e ReaderEntry) raw() ([]byte, error) {
+	if e.IsDir() {
+		return nil, e.error("tree", e.r.treeOffset+int64(e.i)*nodeSize(e.r.format), ErrIsDir)
+	}
+	sz, err := e.n.fileSize(e.r.data())
+	if err != nil {
+		return nil, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), err)
+	}
+	if err := e.r.data().check(e.n.fileDataOffset(), sz); err != nil {
+		return nil, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), err)
+	}
+	if e.r.opt.MaxFileSize > 0 && sz > e.r.opt.MaxFileSize {
+		return nil, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), fmt.Errorf("%w (%d)", ErrMaxFileSize, e.r.opt.MaxFileSize))
+	}
+	buf := make([]byte, sz)
+	if _, err := e.r.data().ReadAt(buf, e.n.fileDataOffset()); err != nil {
+		return nil, e.error("data", e.r.dataOffset+int64(e.n.DataOffset), fmt.Errorf("read data: %w", err))
+	}
+	return buf, nil
 }
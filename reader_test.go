@@ -1,3 +1,350 @@
 package qrc
 
-// TODO
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestReaderLimits(t *testing.T) {
+	build := func(files map[string][]byte) []byte {
+		w := NewWriter()
+		for p, d := range files {
+			if err := w.Add(p, CountryAnyCountry, LanguageC, time.Time{}, d); err != nil {
+				t.Fatalf("add %q: %v", p, err)
+			}
+		}
+		if err := w.Compress(CompressionPolicy{CompressionSettings: CompressionSettings{Compression: CompressionZlib}}); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := w.WriteRCC(&buf, 2); err != nil {
+			t.Fatalf("write rcc: %v", err)
+		}
+		return buf.Bytes()
+	}
+	open := func(b []byte, opt *ReaderOptions) *Reader {
+		r, err := NewReaderFromRCCWithOptions(bytes.NewReader(b), opt)
+		if err != nil {
+			t.Fatalf("read rcc: %v", err)
+		}
+		return r
+	}
+	walk := func(r *Reader) error {
+		return r.Walk(func(path string, entry *ReaderEntry, err error) error {
+			return err
+		}, true)
+	}
+	read := func(r *Reader, name string) error {
+		c, err := r.Children()
+		if err != nil {
+			return err
+		}
+		for _, e := range c {
+			if e.Name() == name {
+				rc, err := e.Open()
+				if err != nil {
+					return err
+				}
+				defer rc.Close()
+				_, err = ioutil.ReadAll(rc)
+				return err
+			}
+		}
+		t.Fatalf("%q not found", name)
+		return nil
+	}
+
+	zeros := make([]byte, 1000)
+	deep := build(map[string][]byte{"a/b/c/d/e.txt": zeros})
+	wide := build(map[string][]byte{"a": zeros, "b": zeros, "c": zeros})
+	long := build(map[string][]byte{strings.Repeat("x", 20): zeros})
+	nested := build(map[string][]byte{"1.rcc": build(map[string][]byte{"2.rcc": build(map[string][]byte{"a": zeros})})})
+
+	cycle := testRCC(t, 2,
+		[]Node{
+			{Flags: NodeFlagDirectory, ChildCount: 1, ChildOffset: 1},
+			{Flags: NodeFlagDirectory, ChildCount: 1, ChildOffset: 1},
+		},
+		testAppend(t, nil, func(b []byte) ([]byte, error) { return AppendName(b, "a") }),
+		nil,
+	)
+
+	for _, tc := range []struct {
+		what string
+		err  error
+		fn   func() error
+	}{
+		{"depth ok", nil, func() error { return walk(open(deep, &ReaderOptions{MaxDepth: 5})) }},
+		{"depth", ErrMaxDepth, func() error { return walk(open(deep, &ReaderOptions{MaxDepth: 4})) }},
+		{"depth unlimited", nil, func() error { return walk(open(deep, &ReaderOptions{MaxDepth: -1})) }},
+		{"cycle", ErrMaxDepth, func() error { return walk(open(cycle, nil)) }},
+		{"nodes ok", nil, func() error { return walk(open(wide, &ReaderOptions{MaxNodes: 4})) }},
+		{"nodes", ErrMaxNodes, func() error { return walk(open(wide, &ReaderOptions{MaxNodes: 3})) }},
+		{"nodes dir", ErrMaxNodes, func() error { _, err := open(wide, &ReaderOptions{MaxNodes: 2}).Children(); return err }},
+		{"nodes nested", ErrMaxNodes, func() error { return walk(open(nested, &ReaderOptions{MaxNodes: 3})) }},
+		{"file size ok", nil, func() error { return read(open(wide, &ReaderOptions{MaxFileSize: 1000}), "a") }},
+		{"file size", ErrMaxFileSize, func() error { return read(open(wide, &ReaderOptions{MaxFileSize: 999}), "a") }},
+		{"total size", ErrMaxTotalSize, func() error {
+			r := open(wide, &ReaderOptions{MaxTotalSize: 2500})
+			for _, n := range []string{"a", "b", "c"} {
+				if err := read(r, n); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"total size nested", ErrMaxTotalSize, func() error { return walk(open(nested, &ReaderOptions{MaxTotalSize: 100})) }},
+		{"name length ok", nil, func() error { _, err := open(long, &ReaderOptions{MaxNameLength: 20}).Children(); return err }},
+		{"name length", ErrMaxNameLength, func() error { _, err := open(long, &ReaderOptions{MaxNameLength: 19}).Children(); return err }},
+		{"nested depth ok", nil, func() error { return walk(open(nested, &ReaderOptions{MaxNestedDepth: 2})) }},
+		{"nested depth", ErrMaxNestedDepth, func() error { return walk(open(nested, &ReaderOptions{MaxNestedDepth: 1})) }},
+	} {
+		if err := tc.fn(); tc.err == nil && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.what, err)
+		} else if !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected error %v, got %v", tc.what, tc.err, err)
+		}
+	}
+
+	var seen []string
+	if err := open(nested, &ReaderOptions{MaxNestedDepth: 1}).Walk(func(path string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			if !errors.Is(err, ErrMaxNestedDepth) {
+				t.Errorf("%s: expected nested depth error, got %v", path, err)
+			}
+			return filepath.SkipDir
+		}
+		seen = append(seen, path)
+		return nil
+	}, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if x := strings.Join(seen, ","); x != "1.rcc" {
+		t.Errorf("expected walk to stop at the nested depth, got %q", x)
+	}
+}
+
+var benchRCCData struct {
+	sync.Once
+	buf []byte
+	err error
+}
+
+func TestReaderPreload(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testWriter(t).WriteRCC(&buf, 2); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+	h, err := ParseRCCHeader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("parse header: %v", err)
+	}
+	treeSize := int64(buf.Len()) - int64(h.TreeOffset)
+
+	// the tree is last, so the inferred size includes the trailing data
+	b := append(buf.Bytes(), make([]byte, 5<<20)...)
+
+	for _, c := range []struct {
+		what  string
+		opt   ReaderOptions
+		tree  bool
+		names bool
+	}{
+		{"none", ReaderOptions{}, false, false},
+		{"default", ReaderOptions{Preload: true}, false, true},
+		{"unlimited", ReaderOptions{Preload: true, PreloadSize: -1}, true, true},
+		{"explicit", ReaderOptions{Preload: true, TreeSize: treeSize}, true, true},
+	} {
+		opt := c.opt
+		r, err := NewReaderFromRCCWithOptions(bytes.NewReader(b), &opt)
+		if err != nil {
+			t.Fatalf("%s: read rcc: %v", c.what, err)
+		}
+		if tree := r.treeRegion.r != r.reader; tree != c.tree {
+			t.Errorf("%s: expected tree preload to be %t", c.what, c.tree)
+		}
+		if names := r.namesRegion.r != r.reader; names != c.names {
+			t.Errorf("%s: expected names preload to be %t", c.what, c.names)
+		}
+		if files := testFiles(t, r); len(files) != 4 {
+			t.Errorf("%s: expected 4 files, got %d", c.what, len(files))
+		}
+	}
+}
+
+func TestReaderEntrySize(t *testing.T) {
+	data := bytes.Repeat([]byte("qrc "), 1000)
+
+	// remove the content size from a single-segment zstd frame
+	zenc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	zf := zenc.EncodeAll(data, nil)
+	if d := zf[4]; d&0x20 == 0 || d&0x03 != 0 {
+		t.Fatalf("expected single-segment zstd frame without dictionary")
+	}
+	zstream := append([]byte(nil), zf[:4]...)
+	zstream = append(zstream, zf[4]&^0xE0, 3<<3) // 8 KiB window
+	zstream = append(zstream, zf[5+[4]int{1, 2, 4, 8}[zf[4]>>6]:]...)
+	if _, fcs, err := zstdFrames(bytes.NewReader(zstream), int64(len(zstream))); err != nil || fcs != -1 {
+		t.Fatalf("expected zstd stream without content size (fcs: %d, err: %v)", fcs, err)
+	}
+
+	w := NewWriter()
+	for _, p := range []string{"none", "zlib", "zstd", "empty"} {
+		d := data
+		if p == "empty" {
+			d = nil
+		}
+		if err := w.Add(p, CountryAnyCountry, LanguageC, time.Time{}, d); err != nil {
+			t.Fatalf("add %q: %v", p, err)
+		}
+	}
+	if err := w.Compress(CompressionPolicy{Overrides: []CompressionOverride{
+		{"zlib", CompressionSettings{Compression: CompressionZlib}},
+		{"zstd", CompressionSettings{Compression: CompressionZstd}},
+	}}); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err := w.AddRaw("zstd-stream", CountryAnyCountry, LanguageC, time.Time{}, NodeFlagCompressedZstd, zstream); err != nil {
+		t.Fatalf("add raw: %v", err)
+	}
+	if err := w.Mkdir("dir", time.Time{}); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	r := testReader(t, w, 3)
+
+	for _, c := range []struct {
+		path string
+		c    Compression
+		usz  int64
+	}{
+		{"none", CompressionNone, int64(len(data))},
+		{"zlib", CompressionZlib, int64(len(data))},
+		{"zstd", CompressionZstd, int64(len(data))},
+		{"zstd-stream", CompressionZstd, int64(len(data))},
+		{"empty", CompressionNone, 0},
+	} {
+		e, err := r.Stat(c.path, CountryAnyCountry, LanguageC)
+		if err != nil {
+			t.Fatalf("stat %q: %v", c.path, err)
+		}
+		if x := e.Compression(); x != c.c {
+			t.Errorf("%s: expected compression %s, got %s", c.path, c.c, x)
+		}
+		csz, err := e.CompressedSize()
+		if err != nil {
+			t.Errorf("%s: compressed size: unexpected error: %v", c.path, err)
+		} else if sz, _ := e.Size(); csz != sz {
+			t.Errorf("%s: compressed size: expected %d, got %d", c.path, sz, csz)
+		}
+		if usz, err := e.UncompressedSize(); err != nil {
+			t.Errorf("%s: uncompressed size: unexpected error: %v", c.path, err)
+		} else if usz != c.usz {
+			t.Errorf("%s: uncompressed size: expected %d, got %d", c.path, c.usz, usz)
+		}
+		if ratio, err := e.Ratio(); err != nil {
+			t.Errorf("%s: ratio: unexpected error: %v", c.path, err)
+		} else if c.c == CompressionNone && ratio != 1 {
+			t.Errorf("%s: ratio: expected 1, got %f", c.path, ratio)
+		} else if c.c != CompressionNone && (ratio <= 0 || ratio >= 0.5) {
+			t.Errorf("%s: ratio: expected (0, 0.5), got %f", c.path, ratio)
+		}
+	}
+
+	e, err := r.Stat("dir", CountryAnyCountry, LanguageC)
+	if err != nil {
+		t.Fatalf("stat dir: %v", err)
+	}
+	if x := e.Compression(); x != CompressionNone {
+		t.Errorf("dir: expected compression none, got %s", x)
+	}
+	if _, err := e.CompressedSize(); !errors.Is(err, ErrIsDir) {
+		t.Errorf("dir: compressed size: expected ErrIsDir, got %v", err)
+	}
+	if _, err := e.UncompressedSize(); !errors.Is(err, ErrIsDir) {
+		t.Errorf("dir: uncompressed size: expected ErrIsDir, got %v", err)
+	}
+	if _, err := e.Ratio(); !errors.Is(err, ErrIsDir) {
+		t.Errorf("dir: ratio: expected ErrIsDir, got %v", err)
+	}
+}
+
+// benchRCC writes a RCC file with 100k files in 1000 directories to a
+// temporary file, and returns the path.
+func benchRCC(b *testing.B) string {
+	benchRCCData.Do(func() {
+		w := NewWriter()
+		for i := 0; i < 100000; i++ {
+			if err := w.Add(fmt.Sprintf("dir%03d/file%05d.txt", i/100, i), CountryAnyCountry, LanguageC, time.Unix(1600000000, 0), []byte(strconv.Itoa(i))); err != nil {
+				benchRCCData.err = err
+				return
+			}
+		}
+		var buf bytes.Buffer
+		benchRCCData.err = w.WriteRCC(&buf, 2)
+		benchRCCData.buf = buf.Bytes()
+	})
+	if benchRCCData.err != nil {
+		b.Fatalf("create rcc: %v", benchRCCData.err)
+	}
+	f, err := ioutil.TempFile("", "qrc-bench-*.rcc")
+	if err != nil {
+		b.Fatalf("create rcc: %v", err)
+	}
+	defer f.Close()
+	b.Cleanup(func() {
+		os.Remove(f.Name())
+	})
+	if _, err := f.Write(benchRCCData.buf); err != nil {
+		b.Fatalf("create rcc: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		b.Fatalf("create rcc: %v", err)
+	}
+	return f.Name()
+}
+
+func BenchmarkWalk(b *testing.B) {
+	for _, opt := range []struct {
+		name string
+		opt  *ReaderOptions
+	}{
+		{"Default", nil},
+		{"Preload", &ReaderOptions{Preload: true}},
+	} {
+		b.Run(opt.name, func(b *testing.B) {
+			f, err := os.Open(benchRCC(b))
+			if err != nil {
+				b.Fatalf("open rcc: %v", err)
+			}
+			defer f.Close()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r, err := NewReaderFromRCCWithOptions(f, opt.opt)
+				if err != nil {
+					b.Fatalf("read rcc: %v", err)
+				}
+				var n int
+				if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+					n++
+					return err
+				}, false); err != nil {
+					b.Fatalf("walk: %v", err)
+				}
+				if n != 101000 {
+					b.Fatalf("expected 101000 entries, got %d", n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package qrc
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// OverrunError is returned when a read goes past the end of the tree, names, or
+// data region of a Reader.
+type OverrunError struct {
+	Region string // tree, names, or data
+	Offset int64  // relative to the start of the region
+	Length int64  // of the read
+	Size   int64  // of the region
+}
+
+func (e *OverrunError) Error() string {
+	return fmt.Sprintf("read of %d bytes at %#x overruns the end of the %s region (size %d) by %d bytes", e.Length, e.Offset, e.Region, e.Size, e.Overrun())
+}
+
+// Overrun returns the number of bytes past the end of the region.
+func (e *OverrunError) Overrun() int64 {
+	return e.Offset + e.Length - e.Size
+}
+
+// regionReader reads from a region of the underlying reader, returning an
+// OverrunError if a read goes past the end.
+type regionReader struct {
+	r      io.ReaderAt
+	region string
+	base   int64
+	size   int64 // -1 if unbounded
+}
+
+// check returns an OverrunError if a read of n bytes at off would go past the
+// end of the region.
+func (r *regionReader) check(off, n int64) error {
+	if off < 0 || n < 0 {
+		return fmt.Errorf("invalid read of %d bytes at %#x in %s region", n, off, r.region)
+	}
+	if r.size >= 0 && off+n > r.size {
+		return &OverrunError{
+			Region: r.region,
+			Offset: off,
+			Length: n,
+			Size:   r.size,
+		}
+	}
+	return nil
+}
+
+func (r *regionReader) ReadAt(p []byte, off int64) (int, error) {
+	if err := r.check(off, int64(len(p))); err != nil {
+		if _, ok := err.(*OverrunError); !ok || off >= r.size {
+			return 0, err
+		}
+		n, rerr := r.r.ReadAt(p[:r.size-off], r.base+off)
+		if rerr != nil && rerr != io.EOF {
+			return n, rerr
+		}
+		return n, err
+	}
+	return r.r.ReadAt(p, r.base+off)
+}
+
+// regionSize infers the size of a region from the start of the next region
+// after it, or the size of the underlying reader. If it can't be determined,
+// -1 is returned.
+func regionSize(r io.ReaderAt, start int64, others ...int64) int64 {
+	end := int64(-1)
+	for _, o := range others {
+		if o > start && (end == -1 || o < end) {
+			end = o
+		}
+	}
+	if sz, ok := readerSize(r); ok && (end == -1 || sz < end) {
+		end = sz
+	}
+	if end == -1 {
+		return -1
+	}
+	if end < start {
+		return 0
+	}
+	return end - start
+}
+
+// readerSize gets the size of r if it has a Size method (e.g. bytes.Reader,
+// io.SectionReader) or is a regular file.
+func readerSize(r io.ReaderAt) (int64, bool) {
+	switch x := r.(type) {
+	case interface{ Size() int64 }:
+		return x.Size(), true
+	case interface{ Stat() (os.FileInfo, error) }:
+		if fi, err := x.Stat(); err == nil && fi.Mode().IsRegular() {
+			return fi.Size(), true
+		}
+	}
+	return 0, false
+}
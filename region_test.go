@@ -0,0 +1,93 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestRegionSize(t *testing.T) {
+	type readerAt struct{ io.ReaderAt }
+	sized := bytes.NewReader(make([]byte, 100))
+	unsized := readerAt{sized}
+
+	for _, tc := range []struct {
+		r      io.ReaderAt
+		start  int64
+		others []int64
+		exp    int64
+	}{
+		{sized, 20, []int64{20, 50, 80}, 30},
+		{sized, 80, []int64{20, 50, 80}, 20},
+		{sized, 20, []int64{10, 200}, 80},
+		{sized, 120, []int64{10}, 0},
+		{unsized, 20, []int64{20, 50, 80}, 30},
+		{unsized, 80, []int64{20, 50, 80}, -1},
+	} {
+		if sz := regionSize(tc.r, tc.start, tc.others...); sz != tc.exp {
+			t.Errorf("%T %d %v: expected %d, got %d", tc.r, tc.start, tc.others, tc.exp, sz)
+		}
+	}
+}
+
+func TestRegionOverrun(t *testing.T) {
+	names := testAppend(t, nil, func(b []byte) ([]byte, error) { return AppendName(b, "a") })
+	data := testAppend(t, nil, func(b []byte) ([]byte, error) { return AppendData(b, []byte("hello")) })
+	file := func(name, data uint32) []Node {
+		return []Node{
+			{Flags: NodeFlagDirectory, ChildCount: 1, ChildOffset: 1},
+			{NameOffset: name, DataOffset: data},
+		}
+	}
+
+	for _, tc := range []struct {
+		what    string
+		tree    []Node
+		opt     *ReaderOptions
+		region  string
+		overrun int64
+	}{
+		{"valid", file(0, 0), nil, "", 0},
+		{"tree", []Node{{Flags: NodeFlagDirectory, ChildCount: 2, ChildOffset: 1}}, nil, "tree", 44},
+		{"names", file(4, 0), nil, "names", 2},
+		{"data", file(0, 6), nil, "data", 1},
+		{"data size", file(0, 0), &ReaderOptions{DataSize: 8}, "data", 1},
+		{"data size ok", file(0, 0), &ReaderOptions{DataSize: 9}, "", 0},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			b := testRCC(t, 2, tc.tree, names, data)
+			r, err := NewReaderFromRCCWithOptions(bytes.NewReader(b), tc.opt)
+			if err != nil {
+				t.Fatalf("read rcc: %v", err)
+			}
+			err = r.Walk(func(path string, entry *ReaderEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !entry.IsDir() {
+					rc, err := entry.Open()
+					if err != nil {
+						return err
+					}
+					defer rc.Close()
+					_, err = io.Copy(ioutil.Discard, rc)
+					return err
+				}
+				return nil
+			}, false)
+
+			var oe *OverrunError
+			if tc.region == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if !errors.As(err, &oe) {
+				t.Errorf("expected overrun error, got %v", err)
+			} else if oe.Region != tc.region || oe.Overrun() != tc.overrun {
+				t.Errorf("expected %s region overrun by %d, got %v", tc.region, tc.overrun, oe)
+			}
+		})
+	}
+}
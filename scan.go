@@ -0,0 +1,87 @@
+package qrc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// scanChunk is the amount of data read at once by ScanRCC.
+const scanChunk = 1 << 16
+
+// EmbeddedRCC is a RCC file found by ScanRCC.
+type EmbeddedRCC struct {
+	Offset int64 // of the header, relative to the io.ReaderAt
+	Reader *Reader
+}
+
+// ScanRCC finds RCC files embedded at arbitrary offsets in r (e.g. ones
+// concatenated into installers or firmware images) by searching for
+// RCCHeaderMagic. Each match is checked by parsing the header, ensuring the
+// offsets are within r (if the size is known), and reading the root directory.
+// Matches which aren't valid RCC files are skipped. Note that stored nested
+// RCC files are also found. The readers are opened with NewReaderFromRCCAt
+// using opt. If an error occurs while reading r, the files found so far are
+// returned with the error.
+func ScanRCC(r io.ReaderAt, opt *ReaderOptions) ([]EmbeddedRCC, error) {
+	var (
+		found []EmbeddedRCC
+		buf   = make([]byte, scanChunk+len(RCCHeaderMagic)-1)
+		off   int64
+	)
+	for {
+		n, err := r.ReadAt(buf, off)
+		for i := 0; ; i++ {
+			j := bytes.Index(buf[i:n], RCCHeaderMagic[:])
+			if j < 0 {
+				break
+			}
+			i += j
+			if rd, ok := probeRCC(r, off+int64(i), opt); ok {
+				found = append(found, EmbeddedRCC{
+					Offset: off + int64(i),
+					Reader: rd,
+				})
+			}
+		}
+		if err == io.EOF {
+			return found, nil
+		}
+		if err != nil {
+			return found, fmt.Errorf("scan at %#x: %w", off, err)
+		}
+		off += int64(n - len(RCCHeaderMagic) + 1) // so matches across chunks are found
+	}
+}
+
+// probeRCC checks whether there is a valid RCC file at base, and if so, opens
+// it.
+func probeRCC(r io.ReaderAt, base int64, opt *ReaderOptions) (*Reader, bool) {
+	h, err := ParseRCCHeader(io.NewSectionReader(r, base, 1<<63-1-base))
+	if err != nil || h.FormatVersion < 1 {
+		return nil, false
+	}
+	size, sized := readerSize(r)
+	for _, o := range []int32{h.TreeOffset, h.DataOffset, h.NamesOffset} {
+		if int(o) < rccHeaderSize(int(h.FormatVersion)) || (sized && base+int64(o) > size) {
+			return nil, false
+		}
+	}
+
+	// don't preload or index false positives
+	x := opt.withDefaults()
+	x.Preload, x.IndexSize, x.IndexEager = false, -1, false
+
+	rd, err := NewReaderFromRCCAt(r, base, &x)
+	if err != nil || !rd.root.IsDir() || rd.root.Flags.Valid() != nil {
+		return nil, false
+	}
+	if _, err := rd.Children(); err != nil {
+		return nil, false
+	}
+
+	if rd, err = NewReaderFromRCCAt(r, base, opt); err != nil {
+		return nil, false
+	}
+	return rd, true
+}
@@ -0,0 +1,65 @@
+package qrc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestScanRCC(t *testing.T) {
+	build := testBuildRCC(t)
+	a := build(CompressionNone, map[string][]byte{"a.txt": []byte("hello")})
+	b := build(CompressionNone, map[string][]byte{"b.txt": bytes.Repeat([]byte("b"), 1000), "n.rcc": a})
+	bogus, _ := RCCHeader{Magic: RCCHeaderMagic, FormatVersion: 3, TreeOffset: 24, DataOffset: 24, NamesOffset: 24}.MarshalBinary()
+
+	var buf bytes.Buffer
+	buf.WriteString("junk qres junk")
+	aOff := int64(buf.Len())
+	buf.Write(a)
+	buf.Write(bogus)
+	buf.Write(make([]byte, 64))
+	buf.WriteString("qres\x00\x00\x00\x09")
+	buf.Write(make([]byte, scanChunk-2-buf.Len())) // across chunks
+	bOff := int64(buf.Len())
+	buf.Write(b)
+	buf.WriteString("qre")
+
+	for _, c := range []struct {
+		what string
+		r    io.ReaderAt
+	}{
+		{"sized", bytes.NewReader(buf.Bytes())},
+		{"unsized", struct{ io.ReaderAt }{bytes.NewReader(buf.Bytes())}},
+	} {
+		found, err := ScanRCC(c.r, nil)
+		if err != nil {
+			t.Fatalf("%s: scan: unexpected error: %v", c.what, err)
+		}
+		if len(found) != 3 {
+			t.Fatalf("%s: expected 3 rcc files, got %d", c.what, len(found))
+		}
+		if found[0].Offset != aOff || found[1].Offset != bOff || found[2].Offset <= bOff {
+			t.Errorf("%s: incorrect offsets: %d %d %d", c.what, found[0].Offset, found[1].Offset, found[2].Offset)
+		}
+		for _, i := range []int{0, 2} {
+			testFilesEqual(t, found[i].Reader, map[string]string{
+				"a.txt": "hello",
+			})
+			e, err := found[i].Reader.Stat("a.txt", CountryAnyCountry, LanguageC)
+			if err != nil {
+				t.Fatalf("%s: stat: %v", c.what, err)
+			}
+			if off := e.Offset(); string(buf.Bytes()[off:off+5]) != "hello" {
+				t.Errorf("%s: expected offset to be relative to the outer file, got %#x", c.what, off)
+			}
+		}
+		testFilesEqual(t, found[1].Reader, map[string]string{
+			"b.txt": string(bytes.Repeat([]byte("b"), 1000)),
+			"n.rcc": string(a),
+		})
+	}
+
+	if _, err := NewReaderFromRCCAt(bytes.NewReader(buf.Bytes()), aOff+1, nil); err == nil {
+		t.Errorf("expected error for incorrect base")
+	}
+}
@@ -0,0 +1,526 @@
+package qrc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// checkpoint is a position in a compressed stream where decompression can be
+// restarted.
+type checkpoint struct {
+	in   int64  // compressed offset in bits
+	out  int64  // uncompressed offset
+	dict []byte // preceding output (deflate only)
+}
+
+// checkpointInterval is the minimum amount of uncompressed data between
+// checkpoints.
+const checkpointInterval = 1 << 20
+
+// inflateWindow is the maximum distance of a deflate back-reference.
+const inflateWindow = 1 << 15
+
+var (
+	errInflateBlockType = errors.New("invalid block type")
+	errInflateStored    = errors.New("stored block length doesn't match complement")
+	errInflateCode      = errors.New("invalid huffman code")
+	errInflateLengths   = errors.New("invalid code lengths")
+	errInflateDistance  = errors.New("distance too far back")
+)
+
+// inflateScanner decodes a raw deflate stream to find block boundaries which
+// can be used as checkpoints (like zlib's examples/zran.c). The output is
+// discarded other than the window needed to restart decompression there. It is
+// a simple bit-by-bit decoder, since it is only used to build checkpoints.
+type inflateScanner struct {
+	r     io.ByteReader
+	in    int64  // bytes read from r
+	b     uint32 // bit buffer
+	nb    uint   // number of bits in b
+	out   int64  // bytes written
+	max   int64  // maximum output, or negative if unlimited
+	final bool   // whether the final block has been read
+	win   [inflateWindow]byte
+	lit   huffman
+	dist  huffman
+}
+
+// huffman is a canonical huffman code.
+type huffman struct {
+	count  [16]uint16  // number of codes of each length
+	symbol [288]uint16 // symbols ordered by code
+}
+
+var (
+	inflateLengthBase  = [29]uint16{3, 4, 5, 6, 7, 8, 9, 10, 11, 13, 15, 17, 19, 23, 27, 31, 35, 43, 51, 59, 67, 83, 99, 115, 131, 163, 195, 227, 258}
+	inflateLengthExtra = [29]uint8{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5, 0}
+	inflateDistBase    = [30]uint16{1, 2, 3, 4, 5, 7, 9, 13, 17, 25, 33, 49, 65, 97, 129, 193, 257, 385, 513, 769, 1025, 1537, 2049, 3073, 4097, 6145, 8193, 12289, 16385, 24577}
+	inflateDistExtra   = [30]uint8{0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8, 9, 9, 10, 10, 11, 11, 12, 12, 13, 13}
+	inflateCodeOrder   = [19]uint8{16, 17, 18, 0, 8, 7, 9, 6, 10, 5, 11, 4, 12, 3, 13, 2, 14, 1, 15}
+
+	inflateFixedLit, inflateFixedDist huffman
+)
+
+func init() {
+	var l [288]uint8
+	for i := range l {
+		switch {
+		case i < 144:
+			l[i] = 8
+		case i < 256:
+			l[i] = 9
+		case i < 280:
+			l[i] = 7
+		default:
+			l[i] = 8
+		}
+	}
+	inflateFixedLit.init(l[:])
+	for i := 0; i < 30; i++ {
+		l[i] = 5
+	}
+	inflateFixedDist.init(l[:30])
+}
+
+// init builds the code from the code lengths for each symbol. Incomplete codes
+// are allowed.
+func (h *huffman) init(lengths []uint8) error {
+	h.count = [16]uint16{}
+	for _, l := range lengths {
+		h.count[l]++
+	}
+	left := 1
+	for l := 1; l < 16; l++ {
+		left = left<<1 - int(h.count[l])
+		if left < 0 {
+			return errInflateLengths
+		}
+	}
+	var offs [16]uint16
+	for l := 1; l < 15; l++ {
+		offs[l+1] = offs[l] + h.count[l]
+	}
+	for s, l := range lengths {
+		if l != 0 {
+			h.symbol[offs[l]] = uint16(s)
+			offs[l]++
+		}
+	}
+	return nil
+}
+
+func newInflateScanner(r io.Reader, max int64) *inflateScanner {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &inflateScanner{r: br, max: max}
+}
+
+func (s *inflateScanner) bits(n uint) (uint32, error) {
+	for s.nb < n {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		s.b |= uint32(c) << s.nb
+		s.nb += 8
+		s.in++
+	}
+	v := s.b & (1<<n - 1)
+	s.b >>= n
+	s.nb -= n
+	return v, nil
+}
+
+func (s *inflateScanner) decode(h *huffman) (int, error) {
+	var code, first, index int
+	for l := 1; l < 16; l++ {
+		b, err := s.bits(1)
+		if err != nil {
+			return 0, err
+		}
+		code |= int(b)
+		count := int(h.count[l])
+		if code-count < first {
+			return int(h.symbol[index+code-first]), nil
+		}
+		index += count
+		first = (first + count) << 1
+		code <<= 1
+	}
+	return 0, errInflateCode
+}
+
+func (s *inflateScanner) write(c byte) error {
+	if s.max >= 0 && s.out >= s.max {
+		return fmt.Errorf("%w (%d)", ErrMaxFileSize, s.max)
+	}
+	s.win[s.out%inflateWindow] = c
+	s.out++
+	return nil
+}
+
+// checkpoint returns a checkpoint at the current position, which must be at a
+// block boundary.
+func (s *inflateScanner) checkpoint() checkpoint {
+	n := s.out
+	if n > inflateWindow {
+		n = inflateWindow
+	}
+	d := make([]byte, n)
+	for i := range d {
+		d[i] = s.win[(s.out-n+int64(i))%inflateWindow]
+	}
+	return checkpoint{
+		in:   s.in*8 - int64(s.nb),
+		out:  s.out,
+		dict: d,
+	}
+}
+
+// next decodes the next block, returning io.EOF after the final one.
+func (s *inflateScanner) next() error {
+	if s.final {
+		return io.EOF
+	}
+	h, err := s.bits(3)
+	if err != nil {
+		return err
+	}
+	s.final = h&1 != 0
+	switch h >> 1 {
+	case 0:
+		return s.stored()
+	case 1:
+		return s.codes(&inflateFixedLit, &inflateFixedDist)
+	case 2:
+		if err := s.dynamic(); err != nil {
+			return err
+		}
+		return s.codes(&s.lit, &s.dist)
+	default:
+		return errInflateBlockType
+	}
+}
+
+func (s *inflateScanner) stored() error {
+	s.b >>= s.nb & 7
+	s.nb -= s.nb & 7
+	n, err := s.bits(16)
+	if err != nil {
+		return err
+	}
+	nc, err := s.bits(16)
+	if err != nil {
+		return err
+	}
+	if n != ^nc&0xFFFF {
+		return errInflateStored
+	}
+	for ; n != 0; n-- {
+		c, err := s.bits(8)
+		if err != nil {
+			return err
+		}
+		if err := s.write(byte(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *inflateScanner) dynamic() error {
+	var lengths [320]uint8
+	h, err := s.bits(14)
+	if err != nil {
+		return err
+	}
+	nlen, ndist, ncode := int(h&0x1F)+257, int(h>>5&0x1F)+1, int(h>>10)+4
+	if nlen > 286 || ndist > 30 {
+		return errInflateLengths
+	}
+	for i := 0; i < ncode; i++ {
+		v, err := s.bits(3)
+		if err != nil {
+			return err
+		}
+		lengths[inflateCodeOrder[i]] = uint8(v)
+	}
+	if err := s.lit.init(lengths[:19]); err != nil {
+		return err
+	}
+	for i := 0; i < nlen+ndist; {
+		sym, err := s.decode(&s.lit)
+		if err != nil {
+			return err
+		}
+		if sym < 16 {
+			lengths[i] = uint8(sym)
+			i++
+			continue
+		}
+		var l uint8
+		var rep uint32
+		switch sym {
+		case 16:
+			if i == 0 {
+				return errInflateLengths
+			}
+			l = lengths[i-1]
+			rep, err = s.bits(2)
+			rep += 3
+		case 17:
+			rep, err = s.bits(3)
+			rep += 3
+		default:
+			rep, err = s.bits(7)
+			rep += 11
+		}
+		if err != nil {
+			return err
+		}
+		if i+int(rep) > nlen+ndist {
+			return errInflateLengths
+		}
+		for ; rep != 0; rep-- {
+			lengths[i] = l
+			i++
+		}
+	}
+	if lengths[256] == 0 {
+		return errInflateLengths
+	}
+	if err := s.lit.init(lengths[:nlen]); err != nil {
+		return err
+	}
+	return s.dist.init(lengths[nlen : nlen+ndist])
+}
+
+func (s *inflateScanner) codes(lit, dist *huffman) error {
+	for {
+		sym, err := s.decode(lit)
+		if err != nil {
+			return err
+		}
+		switch {
+		case sym < 256:
+			if err := s.write(byte(sym)); err != nil {
+				return err
+			}
+		case sym == 256:
+			return nil
+		default:
+			sym -= 257
+			if sym >= 29 {
+				return errInflateCode
+			}
+			e, err := s.bits(uint(inflateLengthExtra[sym]))
+			if err != nil {
+				return err
+			}
+			n := int64(inflateLengthBase[sym]) + int64(e)
+
+			sym, err = s.decode(dist)
+			if err != nil {
+				return err
+			}
+			if sym >= 30 {
+				return errInflateCode
+			}
+			e, err = s.bits(uint(inflateDistExtra[sym]))
+			if err != nil {
+				return err
+			}
+			d := int64(inflateDistBase[sym]) + int64(e)
+			if d > s.out {
+				return errInflateDistance
+			}
+			for ; n != 0; n-- {
+				if err := s.write(s.win[(s.out-d)%inflateWindow]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// inflateResume returns a reader for a raw deflate stream starting at a bit
+// offset within the first byte of r. The bits can't be shifted since stored
+// blocks are aligned to the original byte boundaries, so the bits before the
+// offset are replaced with empty blocks of the same length modulo 8 instead.
+func inflateResume(r io.Reader, shift uint) (io.Reader, error) {
+	if shift == 0 {
+		return r, nil
+	}
+
+	var (
+		buf []byte
+		b   uint32
+		nb  uint
+		n   uint
+	)
+	put := func(v uint32, c uint) { // lsb first
+		b |= v << nb
+		nb += c
+		n += c
+		for nb >= 8 {
+			buf = append(buf, byte(b))
+			b >>= 8
+			nb -= 8
+		}
+	}
+	code := func(v uint32, c uint) { // msb first
+		for ; c != 0; c-- {
+			put(v>>(c-1)&1, 1)
+		}
+	}
+
+	if shift%2 != 0 {
+		// empty dynamic block (95 bits)
+		put(2<<1, 3) // not final, dynamic
+		put(0, 5)    // 257 literal/length codes
+		put(0, 5)    // 1 distance code
+		put(19-4, 4) // 19 code length codes
+		for _, s := range inflateCodeOrder {
+			switch s {
+			case 18:
+				put(1, 3) // 0
+			case 0, 1:
+				put(2, 3) // 10, 11
+			default:
+				put(0, 3)
+			}
+		}
+		code(0, 1)
+		put(138-11, 7) // 138 zeros
+		code(0, 1)
+		put(118-11, 7) // 118 zeros
+		code(3, 2)     // end of block is 1
+		code(2, 2)     // distance code is 0
+		code(0, 1)     // end of block
+	}
+	for n%8 != shift {
+		// empty fixed block (10 bits)
+		put(1<<1, 3) // not final, fixed
+		code(0, 7)   // end of block
+	}
+
+	var c [1]byte
+	if _, err := io.ReadFull(r, c[:]); err != nil {
+		return nil, err
+	}
+	buf = append(buf, byte(b)|c[0]&^(1<<shift-1))
+	return io.MultiReader(bytes.NewReader(buf), r), nil
+}
+
+// zstdFrames returns a checkpoint at the start of the zstd frames in the
+// first size bytes of r, at least checkpointInterval bytes of uncompressed data
+// apart. It also returns the total content size, or -1 if a frame doesn't have
+// it, in which case checkpoints are not returned for the following frames.
+func zstdFrames(r io.ReaderAt, size int64) ([]checkpoint, int64, error) {
+	var (
+		cps  = []checkpoint{{}}
+		out  = int64(0)
+		off  = int64(0)
+		buf  [14]byte
+		read = func(n int) ([]byte, error) {
+			if off+int64(n) > size {
+				return nil, fmt.Errorf("read zstd frame header at %#x: %w", off, io.ErrUnexpectedEOF)
+			}
+			if _, err := r.ReadAt(buf[:n], off); err != nil {
+				return nil, fmt.Errorf("read zstd frame header at %#x: %w", off, err)
+			}
+			off += int64(n)
+			return buf[:n], nil
+		}
+	)
+	for off < size {
+		if out >= 0 && out-cps[len(cps)-1].out >= checkpointInterval {
+			cps = append(cps, checkpoint{in: off * 8, out: out})
+		}
+		b, err := read(4)
+		if err != nil {
+			return nil, 0, err
+		}
+		switch m := binary.LittleEndian.Uint32(b); {
+		case m&0xFFFFFFF0 == 0x184D2A50: // skippable frame
+			b, err := read(4)
+			if err != nil {
+				return nil, 0, err
+			}
+			off += int64(binary.LittleEndian.Uint32(b))
+			continue
+		case m != 0xFD2FB528:
+			return nil, 0, fmt.Errorf("invalid zstd frame magic %#x at %#x", m, off-4)
+		}
+		b, err = read(1)
+		if err != nil {
+			return nil, 0, err
+		}
+		d := b[0]
+		single := d>>5&1 != 0
+		n := [4]int{0, 1, 2, 4}[d&3]
+		if !single {
+			n++
+		}
+		fcs := [4]int{0, 2, 4, 8}[d>>6]
+		if fcs == 0 && single {
+			fcs = 1
+		}
+		if b, err = read(n + fcs); err != nil {
+			return nil, 0, err
+		}
+		switch b = b[n:]; fcs {
+		case 0:
+			out = -1
+		case 1:
+			out += int64(b[0])
+		case 2:
+			out += int64(binary.LittleEndian.Uint16(b)) + 256
+		case 4:
+			out += int64(binary.LittleEndian.Uint32(b))
+		case 8:
+			if x := binary.LittleEndian.Uint64(b); x > 1<<62 {
+				out = -1
+			} else if out >= 0 {
+				out += int64(x)
+			}
+		}
+		for {
+			b, err := read(3)
+			if err != nil {
+				return nil, 0, err
+			}
+			h := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+			switch h >> 1 & 3 {
+			case 0, 2: // raw, compressed
+				off += int64(h >> 3)
+			case 1: // rle
+				off++
+			default:
+				return nil, 0, fmt.Errorf("invalid zstd block type at %#x", off-3)
+			}
+			if h&1 != 0 {
+				break
+			}
+		}
+		if d>>2&1 != 0 {
+			off += 4 // checksum
+		}
+		if out < 0 {
+			return cps, -1, nil
+		}
+	}
+	if off > size {
+		return nil, 0, fmt.Errorf("zstd frame runs past the end of the data by %d bytes", off-size)
+	}
+	return cps, out, nil
+}
@@ -0,0 +1,134 @@
+package qrc
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// testSeekData returns compressible pseudo-random data.
+func testSeekData(n int) []byte {
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"qt", "resource", "tree", "names", "data", "zlib", "zstd", "\n"}
+	var b bytes.Buffer
+	for b.Len() < n {
+		if rng.Intn(64) == 0 {
+			for i := 0; i < 64; i++ {
+				b.WriteByte(byte(rng.Intn(256)))
+			}
+		}
+		b.WriteString(words[rng.Intn(len(words))])
+		b.WriteString(strconv.Itoa(rng.Intn(1000)))
+		b.WriteByte(' ')
+	}
+	return b.Bytes()[:n]
+}
+
+func TestInflateScanner(t *testing.T) {
+	data := testSeekData(2 << 20)
+	shifts := map[int64]bool{}
+	for _, level := range []int{flate.NoCompression, flate.BestSpeed, 2, 4, flate.DefaultCompression, flate.BestCompression, flate.HuffmanOnly} {
+		t.Run(fmt.Sprint(level), func(t *testing.T) {
+			var buf bytes.Buffer
+			zw, _ := flate.NewWriter(&buf, level)
+			zw.Write(data)
+			zw.Close()
+
+			s := newInflateScanner(bytes.NewReader(buf.Bytes()), -1)
+			var cps []checkpoint
+			for {
+				if !s.final {
+					cps = append(cps, s.checkpoint())
+				}
+				if err := s.next(); err != nil {
+					if err != io.EOF {
+						t.Fatalf("scan: unexpected error: %v", err)
+					}
+					break
+				}
+			}
+			if s.out != int64(len(data)) {
+				t.Fatalf("scan: expected %d bytes of output, got %d", len(data), s.out)
+			}
+			for _, cp := range cps {
+				shifts[cp.in%8] = true
+				ir, err := inflateResume(bytes.NewReader(buf.Bytes()[cp.in/8:]), uint(cp.in%8))
+				if err != nil {
+					t.Fatalf("checkpoint %d: %v", cp.out, err)
+				}
+				b, err := ioutil.ReadAll(io.LimitReader(flate.NewReaderDict(ir, cp.dict), 1<<12))
+				if err != nil {
+					t.Errorf("checkpoint %d: decompress: unexpected error: %v", cp.out, err)
+				} else if !bytes.Equal(b, data[cp.out:minInt(int(cp.out)+1<<12, len(data))]) {
+					t.Errorf("checkpoint %d: incorrect data", cp.out)
+				}
+			}
+		})
+	}
+	if len(shifts) != 8 {
+		t.Errorf("expected checkpoints at every bit offset, got %v", shifts)
+	}
+	t.Run("Limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw, _ := flate.NewWriter(&buf, flate.BestSpeed)
+		zw.Write(data)
+		zw.Close()
+
+		s := newInflateScanner(bytes.NewReader(buf.Bytes()), 1000)
+		for {
+			if err := s.next(); err != nil {
+				if !errors.Is(err, ErrMaxFileSize) {
+					t.Errorf("expected ErrMaxFileSize, got %v", err)
+				}
+				break
+			}
+		}
+	})
+}
+
+func TestZstdFrames(t *testing.T) {
+	data := testSeekData(5 << 20)
+
+	enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	var z []byte
+	for i := 0; i < len(data); i += 1 << 19 {
+		z = enc.EncodeAll(data[i:minInt(i+1<<19, len(data))], z)
+	}
+	z = append(z, 0x50, 0x2A, 0x4D, 0x18, 2, 0, 0, 0, 0xAA, 0xBB) // skippable frame
+
+	cps, sz, err := zstdFrames(bytes.NewReader(z), int64(len(z)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sz != int64(len(data)) {
+		t.Errorf("expected size %d, got %d", len(data), sz)
+	}
+	if len(cps) != 6 {
+		t.Errorf("expected 6 checkpoints, got %d", len(cps))
+	}
+	for _, cp := range cps {
+		dec, _ := zstd.NewReader(bytes.NewReader(z[cp.in/8:]), zstd.WithDecoderConcurrency(1))
+		b, err := ioutil.ReadAll(dec)
+		dec.Close()
+		if err != nil {
+			t.Errorf("checkpoint %d: decompress: unexpected error: %v", cp.out, err)
+		} else if !bytes.Equal(b, data[cp.out:]) {
+			t.Errorf("checkpoint %d: incorrect data", cp.out)
+		}
+	}
+
+	if _, _, err := zstdFrames(bytes.NewReader(z), int64(len(z)-1)); err == nil {
+		t.Errorf("expected error for truncated data")
+	}
+	if _, _, err := zstdFrames(bytes.NewReader(append([]byte{1, 2, 3, 4}, z...)), int64(len(z)+4)); err == nil {
+		t.Errorf("expected error for invalid magic")
+	}
+}
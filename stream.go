@@ -0,0 +1,374 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+)
+
+// StreamReader reads a RCC file from an io.Reader in a single pass, like
+// tar.Reader. The tree and names regions are buffered in memory (up to
+// ReaderOptions.MaxFileSize). If the data region is before either of them
+// (which is the layout written by rcc and Writer), it is read into a temporary
+// file (see ReaderOptions.SpillSize, which defaults to 4 MiB for StreamReader)
+// before the entries can be read. Otherwise, it is read directly from the
+// io.Reader as the entries are read.
+//
+// Entries are returned by Next, starting with the directories in tree order,
+// then the files in the order of their data. The contents of the current file
+// can be read with StreamReader.Read, or with ReaderEntry.Open (or other
+// methods which read the data, including ReaderEntry.OpenRCC for nested RCC
+// files, which aren't expanded automatically). Unless the data was read into a
+// temporary file, it can't be read again after calling Next.
+type StreamReader struct {
+	r       *Reader
+	data    *streamData // nil if the data was read into a temporary file
+	entries []streamEntry
+	i       int
+	cur     *streamEntry // or nil
+	f       *ReaderFile  // for cur, or nil if not opened yet
+	err     error        // sticky error for Read
+}
+
+type streamEntry struct {
+	iterEntry
+	shared bool // another file has the same data
+}
+
+// NewStreamReader reads the header, tree, and names regions of a RCC file
+// from r. If opt is nil, the default limits are used.
+func NewStreamReader(r io.Reader, opt *ReaderOptions) (*StreamReader, error) {
+	src := &streamSource{r: r}
+
+	h, err := ParseRCCHeader(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse rcc header: %w", &Error{
+			Region: "header",
+			Err:    err,
+		})
+	}
+
+	x := opt.withDefaults()
+	x.Preload = false // already in memory
+	if x.SpillSize == 0 {
+		x.SpillSize = 4 << 20
+	}
+
+	regions := []struct {
+		region string
+		offset int64
+		size   *int64
+	}{
+		{"tree", int64(h.TreeOffset), &x.TreeSize},
+		{"data", int64(h.DataOffset), &x.DataSize},
+		{"names", int64(h.NamesOffset), &x.NamesSize},
+	}
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].offset < regions[j].offset
+	})
+
+	var (
+		data    *streamData
+		release func() error
+	)
+	for i, rg := range regions {
+		if rg.offset < src.pos {
+			if release != nil {
+				release()
+			}
+			return nil, fmt.Errorf("read %s region: %w", rg.region, &Error{
+				Region: rg.region,
+				Offset: rg.offset,
+				Err:    fmt.Errorf("offset before end of previous region (%#x)", src.pos),
+			})
+		}
+		size := int64(-1) // until EOF
+		if i+1 < len(regions) {
+			size = regions[i+1].offset - rg.offset
+		}
+		if err := src.skip(rg.offset); err != nil {
+			if release != nil {
+				release()
+			}
+			return nil, fmt.Errorf("read %s region: %w", rg.region, &Error{
+				Region: rg.region,
+				Offset: rg.offset,
+				Err:    err,
+			})
+		}
+		if rg.region == "data" && size < 0 {
+			data = &streamData{
+				r:    r,
+				pos:  rg.offset,
+				keep: rg.offset,
+			}
+			src.data = data
+			break
+		}
+
+		var (
+			ra  io.ReaderAt
+			err error
+		)
+		if rg.region == "data" {
+			ra, release, err = x.spill(io.LimitReader(src, size))
+		} else {
+			var buf []byte
+			switch {
+			case x.MaxFileSize > 0 && size > x.MaxFileSize:
+				err = fmt.Errorf("%w (%d)", ErrMaxFileSize, x.MaxFileSize)
+			case x.MaxFileSize > 0 && size < 0:
+				if buf, err = ioutil.ReadAll(io.LimitReader(src, x.MaxFileSize+1)); err == nil && int64(len(buf)) > x.MaxFileSize {
+					err = fmt.Errorf("%w (%d)", ErrMaxFileSize, x.MaxFileSize)
+				}
+			case size < 0:
+				buf, err = ioutil.ReadAll(src)
+			default:
+				buf, err = ioutil.ReadAll(io.LimitReader(src, size))
+			}
+			ra = bytes.NewReader(buf)
+		}
+		if err == nil && size >= 0 {
+			if sz, _ := readerSize(ra); sz != size {
+				err = io.ErrUnexpectedEOF
+			}
+		}
+		if err != nil {
+			if release != nil {
+				release()
+			}
+			return nil, fmt.Errorf("read %s region: %w", rg.region, &Error{
+				Region: rg.region,
+				Offset: rg.offset,
+				Err:    err,
+			})
+		}
+		src.regions = append(src.regions, streamRegion{rg.offset, ra})
+		if sz, _ := readerSize(ra); sz != 0 {
+			*rg.size = sz // otherwise, let NewReader infer it
+		}
+	}
+
+	rd, err := NewReaderWithOptions(src, int(h.FormatVersion), int64(h.TreeOffset), int64(h.DataOffset), int64(h.NamesOffset), &x)
+	if err != nil {
+		if release != nil {
+			release()
+		}
+		return nil, err
+	}
+	rd.release = release
+
+	var nodes int
+	entries, err := dataOrder("", &ReaderEntry{n: rd.root, r: rd}, &nodes)
+	if err == nil {
+		for _, x := range entries {
+			if x.err != nil {
+				err = x.err
+				break
+			}
+		}
+	}
+	if err != nil {
+		rd.Close()
+		return nil, fmt.Errorf("read tree: %w", err)
+	}
+
+	s := &StreamReader{
+		r:       rd,
+		data:    data,
+		entries: make([]streamEntry, len(entries)),
+	}
+	for i, x := range entries {
+		s.entries[i].iterEntry = x
+		if !x.entry.IsDir() && i+1 < len(entries) {
+			s.entries[i].shared = x.entry.n.DataOffset == entries[i+1].entry.n.DataOffset
+		}
+	}
+	return s, nil
+}
+
+// Reader returns the underlying Reader. Note that if the data is being read
+// directly from the io.Reader, only the data for the current entry can be read.
+func (s *StreamReader) Reader() *Reader {
+	return s.r
+}
+
+// Next advances to the next entry, returning it along with the path. At the
+// end of the RCC file, io.EOF is returned.
+func (s *StreamReader) Next() (*ReaderEntry, string, error) {
+	if s.f != nil {
+		s.f.Close()
+		s.f = nil
+	}
+	s.cur, s.err = nil, nil
+	if s.i >= len(s.entries) {
+		return nil, "", io.EOF
+	}
+	s.cur = &s.entries[s.i]
+	s.i++
+	if s.data != nil && !s.cur.entry.IsDir() {
+		s.data.seek(s.r.dataOffset + int64(s.cur.entry.n.DataOffset))
+	}
+	return s.cur.entry, s.cur.path, nil
+}
+
+// Read reads from the contents of the current file. If the current entry is a
+// directory, io.EOF is returned.
+func (s *StreamReader) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.cur == nil || s.cur.entry.IsDir() {
+		return 0, io.EOF
+	}
+	if s.f == nil {
+		if s.f, s.err = s.cur.entry.OpenFile(); s.err != nil {
+			return 0, s.err
+		}
+		if s.data != nil && !s.cur.shared {
+			s.data.discard() // nothing else will read the data before the current position
+		}
+	}
+	return s.f.Read(p)
+}
+
+// Close releases the temporary file, if any.
+func (s *StreamReader) Close() error {
+	if s.f != nil {
+		s.f.Close()
+		s.f = nil
+	}
+	return s.r.Close()
+}
+
+// streamSource is the io.ReaderAt for a StreamReader. Reads from the buffered
+// regions are served from memory, and other reads go to the data.
+type streamSource struct {
+	r       io.Reader
+	pos     int64 // of r, while reading the regions
+	regions []streamRegion
+	data    *streamData // or nil
+}
+
+type streamRegion struct {
+	offset int64
+	r      io.ReaderAt
+}
+
+func (s *streamSource) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.pos += int64(n)
+	return n, err
+}
+
+// skip discards data until the offset.
+func (s *streamSource) skip(off int64) error {
+	n, err := io.CopyN(ioutil.Discard, s, off-s.pos)
+	if err == io.EOF && n != off-s.pos {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (s *streamSource) ReadAt(p []byte, off int64) (int, error) {
+	for _, rg := range s.regions {
+		if sz, _ := readerSize(rg.r); off >= rg.offset && off < rg.offset+sz {
+			return rg.r.ReadAt(p, off-rg.offset)
+		}
+	}
+	if s.data != nil {
+		return s.data.ReadAt(p, off)
+	}
+	return 0, io.EOF
+}
+
+// streamData reads the data region directly from an io.Reader. It buffers the
+// data after keep so it can be read again. It is safe for concurrent use since
+// the zstd decoder reads in the background.
+type streamData struct {
+	mu     sync.Mutex
+	r      io.Reader
+	pos    int64  // of r
+	buf    []byte // data before pos, starting at keep
+	keep   int64  // offset before which data can be discarded
+	follow bool   // whether to discard the data before each read
+	err    error  // from r
+}
+
+// errStreamBackwards is returned when attempting to read data which has
+// already been discarded.
+var errStreamBackwards = errors.New("stream: data has already been read")
+
+// seek discards the data before off, which must not be before any earlier
+// offset passed to seek.
+func (d *streamData) seek(off int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.keep, d.follow = off, false
+}
+
+// discard makes the data before each read be discarded until the next seek.
+func (d *streamData) discard() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.follow = true
+}
+
+func (d *streamData) ReadAt(p []byte, off int64) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.follow && off > d.keep {
+		d.keep = off
+	}
+	if start := d.pos - int64(len(d.buf)); d.keep > start {
+		if d.keep >= d.pos {
+			d.buf = d.buf[:0]
+		} else {
+			d.buf = append(d.buf[:0], d.buf[d.keep-start:]...)
+		}
+	}
+	if d.keep > d.pos && d.err == nil {
+		n, err := io.CopyN(ioutil.Discard, d.r, d.keep-d.pos)
+		d.pos += n
+		if err != nil {
+			d.err = err
+		}
+	}
+	start := d.pos - int64(len(d.buf))
+	if off < start {
+		return 0, fmt.Errorf("%w (offset %#x, buffered from %#x)", errStreamBackwards, off, start)
+	}
+	if end := off + int64(len(p)); end > d.pos && d.err == nil {
+		n, need := len(d.buf), int(end-d.pos)
+		if cap(d.buf)-n < need {
+			buf := make([]byte, n, 2*cap(d.buf)+need)
+			copy(buf, d.buf)
+			d.buf = buf
+		}
+		d.buf = d.buf[:n+need]
+		k, err := io.ReadFull(d.r, d.buf[n:])
+		d.buf = d.buf[:n+k]
+		d.pos += int64(k)
+		if err != nil {
+			d.err = err
+		}
+	}
+	if off >= d.pos {
+		if d.err == nil || d.err == io.ErrUnexpectedEOF {
+			return 0, io.EOF
+		}
+		return 0, d.err
+	}
+	n := copy(p, d.buf[off-start:])
+	if n < len(p) {
+		if d.err == nil || d.err == io.ErrUnexpectedEOF {
+			return n, io.EOF
+		}
+		return n, d.err
+	}
+	return n, nil
+}
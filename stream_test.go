@@ -0,0 +1,222 @@
+package qrc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestStreamReader(t *testing.T) {
+	files := map[string][]byte{
+		"a.txt":       []byte("hello"),
+		"b/c.txt":     bytes.Repeat([]byte("c"), 5000),
+		"b/d.txt":     testSeekData(200000),
+		"b/dup.txt":   bytes.Repeat([]byte("c"), 5000),
+		"e/empty.txt": nil,
+	}
+
+	dir, err := ioutil.TempDir("", "qrc")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, c := range []Compression{CompressionNone, CompressionZlib, CompressionZstd} {
+		w := NewWriter()
+		for p, d := range files {
+			if err := w.Add(p, CountryAnyCountry, LanguageC, time.Time{}, d); err != nil {
+				t.Fatalf("add %q: %v", p, err)
+			}
+		}
+		if err := w.Compress(CompressionPolicy{CompressionSettings: CompressionSettings{Compression: c}}); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		if _, err := w.Deduplicate(); err != nil {
+			t.Fatalf("deduplicate: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := w.WriteRCC(&buf, 3); err != nil {
+			t.Fatalf("write rcc: %v", err)
+		}
+
+		for _, x := range []struct {
+			what   string
+			rcc    []byte
+			opt    ReaderOptions
+			stream bool // data is read directly from the io.Reader
+			spill  bool
+		}{
+			{"data first", buf.Bytes(), ReaderOptions{}, false, false},
+			{"data first spill", buf.Bytes(), ReaderOptions{SpillSize: 100, SpillDir: dir}, false, true},
+			{"data first no spill", buf.Bytes(), ReaderOptions{SpillSize: -1, SpillDir: dir}, false, false},
+			{"data last", testStreamLayout(t, buf.Bytes()), ReaderOptions{}, true, false},
+		} {
+			what := c.String() + " " + x.what
+			opt := x.opt
+			s, err := NewStreamReader(iotest.HalfReader(bytes.NewReader(x.rcc)), &opt)
+			if err != nil {
+				t.Fatalf("%s: new stream reader: %v", what, err)
+			}
+			if (s.data != nil) != x.stream {
+				t.Errorf("%s: expected stream to be %t", what, x.stream)
+			}
+			var shared int
+			for _, e := range s.entries {
+				if e.shared {
+					shared++
+				}
+			}
+			if shared != 1 {
+				t.Errorf("%s: expected 1 file to share data with the next one, got %d", what, shared)
+			}
+			it := s.Reader().Iter(false, IterDataOrder)
+			for _, e := range s.entries {
+				if _, p, err := it.Next(); err != nil || p != e.path {
+					t.Errorf("%s: expected the same order as IterDataOrder, got %q (err: %v) instead of %q", what, p, err, e.path)
+					break
+				}
+			}
+			it.Close()
+			if fs, _ := ioutil.ReadDir(dir); (len(fs) != 0) != x.spill {
+				t.Errorf("%s: expected spill to be %t, got %d temp files", what, x.spill, len(fs))
+			}
+
+			var (
+				last  int64
+				dirs  int
+				found = map[string][]byte{}
+			)
+			for {
+				e, p, err := s.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("%s: next: %v", what, err)
+				}
+				if e.IsDir() {
+					if len(found) != 0 {
+						t.Errorf("%s: %q: expected directories first", what, p)
+					}
+					dirs++
+					continue
+				}
+				if e.Offset() < last {
+					t.Errorf("%s: %q: expected files in data order", what, p)
+				}
+				last = e.Offset()
+				if p == "b/d.txt" {
+					if _, err := io.CopyN(ioutil.Discard, s, 10); err != nil {
+						t.Errorf("%s: %q: read: %v", what, p, err)
+					}
+					continue // skip the rest
+				}
+				b, err := ioutil.ReadAll(s)
+				if err != nil {
+					t.Fatalf("%s: %q: read: %v", what, p, err)
+				}
+				found[p] = b
+			}
+			if dirs != 2 {
+				t.Errorf("%s: expected 2 dirs, got %d", what, dirs)
+			}
+			for p, d := range files {
+				if p == "b/d.txt" {
+					continue
+				}
+				if b, ok := found[p]; !ok {
+					t.Errorf("%s: %q: missing", what, p)
+				} else if !bytes.Equal(b, d) {
+					t.Errorf("%s: %q: incorrect contents", what, p)
+				}
+			}
+
+			if x.stream {
+				e, err := s.Reader().Stat("a.txt", CountryAnyCountry, LanguageC)
+				if err != nil {
+					t.Fatalf("%s: stat: %v", what, err)
+				}
+				if _, err := e.UncompressedSize(); e.Compression() != CompressionNone && !errors.Is(err, errStreamBackwards) {
+					t.Errorf("%s: expected error reading data which has already been read, got %v", what, err)
+				}
+			}
+			if err := s.Close(); err != nil {
+				t.Errorf("%s: close: %v", what, err)
+			}
+			if fs, _ := ioutil.ReadDir(dir); len(fs) != 0 {
+				t.Errorf("%s: expected temp file to be removed, got %d files", what, len(fs))
+			}
+		}
+	}
+
+	if _, err := NewStreamReader(bytes.NewReader([]byte("qres\x00\x00\x00\x03")), nil); err == nil {
+		t.Errorf("expected error for truncated header")
+	}
+
+	w := NewWriter()
+	if err := w.Add("big", CountryAnyCountry, LanguageC, time.Time{}, testSeekData(5<<20)); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := w.WriteRCC(&buf, 3); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+	if s, err := NewStreamReader(bytes.NewReader(buf.Bytes()), &ReaderOptions{SpillDir: dir}); err != nil {
+		t.Errorf("default spill: new stream reader: %v", err)
+	} else {
+		if fs, _ := ioutil.ReadDir(dir); len(fs) != 1 {
+			t.Errorf("default spill: expected data to be read into a temp file, got %d files", len(fs))
+		}
+		s.Close()
+	}
+
+	h := RCCHeader{Magic: RCCHeaderMagic, FormatVersion: 3, TreeOffset: 24, NamesOffset: 0x7fff0000, DataOffset: 0x7fffff00}
+	b, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal header: %v", err)
+	}
+	if _, err := NewStreamReader(bytes.NewReader(b), nil); !errors.Is(err, ErrMaxFileSize) {
+		t.Errorf("expected ErrMaxFileSize for huge tree region, got %v", err)
+	}
+
+	h = RCCHeader{Magic: RCCHeaderMagic, FormatVersion: 3, DataOffset: 24, NamesOffset: 32, TreeOffset: 40}
+	if b, err = h.MarshalBinary(); err != nil {
+		t.Fatalf("marshal header: %v", err)
+	}
+	if _, err := NewStreamReader(io.MultiReader(bytes.NewReader(b), bytes.NewReader(make([]byte, 1000))), &ReaderOptions{MaxFileSize: 100}); !errors.Is(err, ErrMaxFileSize) {
+		t.Errorf("expected ErrMaxFileSize for tree region larger than MaxFileSize, got %v", err)
+	}
+}
+
+// testStreamLayout rewrites a RCC file written by Writer so the data region is
+// after the tree and names regions.
+func testStreamLayout(t *testing.T, rcc []byte) []byte {
+	h, err := ParseRCCHeader(bytes.NewReader(rcc))
+	if err != nil {
+		t.Fatalf("parse header: %v", err)
+	}
+	if !(h.DataOffset < h.NamesOffset && h.NamesOffset < h.TreeOffset) {
+		t.Fatalf("unexpected layout")
+	}
+	hs := int32(rccHeaderSize(int(h.FormatVersion)))
+	data := rcc[h.DataOffset:h.NamesOffset]
+	names := rcc[h.NamesOffset:h.TreeOffset]
+	tree := rcc[h.TreeOffset:]
+
+	h.TreeOffset = hs
+	h.NamesOffset = hs + int32(len(tree))
+	h.DataOffset = hs + int32(len(tree)+len(names))
+	b, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal header: %v", err)
+	}
+	b = append(b, tree...)
+	b = append(b, names...)
+	b = append(b, data...)
+	return b
+}
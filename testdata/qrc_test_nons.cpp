@@ -0,0 +1,115 @@
+/****************************************************************************
+** Resource object code
+**
+** Created by: github.com/pgaskin/qrc
+**
+** WARNING! All changes made in this file will be lost!
+*****************************************************************************/
+
+static const unsigned char qt_resource_data[] = {
+  // /main.qml
+  0x0,0x0,0x0,0x1c,
+  0x69,
+  0x6d,0x70,0x6f,0x72,0x74,0x20,0x51,0x74,0x51,0x75,0x69,0x63,0x6b,0x20,0x32,0x2e,
+  0x30,0xa,0xa,0x49,0x74,0x65,0x6d,0x20,0x7b,0x7d,0xa,
+    // /i18n/hello.txt
+  0x0,0x0,0x0,0x5,
+  0x68,
+  0x65,0x6c,0x6c,0x6f,
+    // /i18n/hello.txt
+  0x0,0x0,0x0,0x7,
+  0x62,
+  0x6f,0x6e,0x6a,0x6f,0x75,0x72,
+    // /img/icon.svg
+  0x0,0x0,0x0,0x15,
+  0x0,
+  0x0,0x0,0x78,0x78,0xda,0xb2,0x29,0x2e,0x4b,0xd7,0xb7,0xa3,0x3f,0x9,0x18,0x0,
+  0x5c,0x13,0x27,0x75,
+  
+};
+
+static const unsigned char qt_resource_name[] = {
+  // img
+  0x0,0x3,
+  0x0,0x0,0x70,0x37,
+  0x0,0x69,
+  0x0,0x6d,0x0,0x67,
+    // i18n
+  0x0,0x4,
+  0x0,0x6,0xc4,0xee,
+  0x0,0x69,
+  0x0,0x31,0x0,0x38,0x0,0x6e,
+    // main.qml
+  0x0,0x8,
+  0x8,0x1,0x5a,0x5c,
+  0x0,0x6d,
+  0x0,0x61,0x0,0x69,0x0,0x6e,0x0,0x2e,0x0,0x71,0x0,0x6d,0x0,0x6c,
+    // hello.txt
+  0x0,0x9,
+  0x3,0x32,0x86,0x74,
+  0x0,0x68,
+  0x0,0x65,0x0,0x6c,0x0,0x6c,0x0,0x6f,0x0,0x2e,0x0,0x74,0x0,0x78,0x0,0x74,
+    // icon.svg
+  0x0,0x8,
+  0xa,0x61,0x57,0x27,
+  0x0,0x69,
+  0x0,0x63,0x0,0x6f,0x0,0x6e,0x0,0x2e,0x0,0x73,0x0,0x76,0x0,0x67,
+  
+};
+
+static const unsigned char qt_resource_struct[] = {
+  // :
+  0x0,0x0,0x0,0x0,0x0,0x2,0x0,0x0,0x0,0x3,0x0,0x0,0x0,0x1,
+  // :/img
+  0x0,0x0,0x0,0x0,0x0,0x2,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x6,
+  // :/i18n
+  0x0,0x0,0x0,0xc,0x0,0x2,0x0,0x0,0x0,0x2,0x0,0x0,0x0,0x4,
+  // :/main.qml
+  0x0,0x0,0x0,0x1a,0x0,0x0,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x0,
+  // :/i18n/hello.txt
+  0x0,0x0,0x0,0x30,0x0,0x0,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x20,
+  // :/i18n/hello.txt [38::37[
+  0x0,0x0,0x0,0x30,0x0,0x0,0x0,0x26,0x0,0x25,0x0,0x0,0x0,0x29,
+  // :/img/icon.svg
+  0x0,0x0,0x0,0x48,0x0,0x1,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x34,
+
+};
+
+bool qRegisterResourceData(int, const unsigned char *, const unsigned char *, const unsigned char *);
+bool qUnregisterResourceData(int, const unsigned char *, const unsigned char *, const unsigned char *);
+
+#if defined(__ELF__) || defined(__APPLE__)
+static inline unsigned char qResourceFeatureZlib()
+{
+    extern const unsigned char qt_resourceFeatureZlib;
+    return qt_resourceFeatureZlib;
+}
+#else
+unsigned char qResourceFeatureZlib();
+#endif
+
+int qInitResources_test_1();
+int qInitResources_test_1()
+{
+    int version = 1;
+    qRegisterResourceData
+        (version, qt_resource_struct, qt_resource_name, qt_resource_data);
+    return 1;
+}
+
+int qCleanupResources_test_1();
+int qCleanupResources_test_1()
+{
+    int version = 1;
+    version += qResourceFeatureZlib();
+    qUnregisterResourceData
+       (version, qt_resource_struct, qt_resource_name, qt_resource_data);
+    return 1;
+}
+
+namespace {
+   struct initializer {
+       initializer() { qInitResources_test_1(); }
+       ~initializer() { qCleanupResources_test_1(); }
+   } dummy;
+}
@@ -0,0 +1,69 @@
+# -*- coding: utf-8 -*-
+
+# Resource object code
+#
+# Created by: github.com/pgaskin/qrc
+#
+# WARNING! All changes made in this file will be lost!
+
+from PySide6 import QtCore
+
+qt_resource_data = b"\
+\x00\x00\x00\x1c\
+\x69\
+\x6d\x70\x6f\x72\x74\x20\x51\x74\x51\x75\x69\x63\x6b\x20\x32\x2e\
+\x30\x0a\x0a\x49\x74\x65\x6d\x20\x7b\x7d\x0a\
+\x00\x00\x00\x05\
+\x68\
+\x65\x6c\x6c\x6f\
+\x00\x00\x00\x07\
+\x62\
+\x6f\x6e\x6a\x6f\x75\x72\
+\x00\x00\x00\x15\
+\x00\
+\x00\x00\x78\x78\xda\xb2\x29\x2e\x4b\xd7\xb7\xa3\x3f\x09\x18\x00\
+\x5c\x13\x27\x75\
+"
+
+qt_resource_name = b"\
+\x00\x03\
+\x00\x00\x70\x37\
+\x00\x69\
+\x00\x6d\x00\x67\
+\x00\x04\
+\x00\x06\xc4\xee\
+\x00\x69\
+\x00\x31\x00\x38\x00\x6e\
+\x00\x08\
+\x08\x01\x5a\x5c\
+\x00\x6d\
+\x00\x61\x00\x69\x00\x6e\x00\x2e\x00\x71\x00\x6d\x00\x6c\
+\x00\x09\
+\x03\x32\x86\x74\
+\x00\x68\
+\x00\x65\x00\x6c\x00\x6c\x00\x6f\x00\x2e\x00\x74\x00\x78\x00\x74\
+\x00\x08\
+\x0a\x61\x57\x27\
+\x00\x69\
+\x00\x63\x00\x6f\x00\x6e\x00\x2e\x00\x73\x00\x76\x00\x67\
+"
+
+qt_resource_struct = b"\
+\x00\x00\x00\x00\x00\x02\x00\x00\x00\x03\x00\x00\x00\x01\
+\x00\x00\x00\x00\x00\x02\x00\x00\x00\x01\x00\x00\x00\x06\
+\x00\x00\x00\x0c\x00\x02\x00\x00\x00\x02\x00\x00\x00\x04\
+\x00\x00\x00\x1a\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\
+\x00\x00\x00\x30\x00\x00\x00\x00\x00\x01\x00\x00\x00\x20\
+\x00\x00\x00\x30\x00\x00\x00\x26\x00\x25\x00\x00\x00\x29\
+\x00\x00\x00\x48\x00\x01\x00\x00\x00\x01\x00\x00\x00\x34\
+"
+
+rcc_version = 1
+
+def qInitResources():
+    QtCore.qRegisterResourceData(rcc_version, qt_resource_struct, qt_resource_name, qt_resource_data)
+
+def qCleanupResources():
+    QtCore.qUnregisterResourceData(rcc_version, qt_resource_struct, qt_resource_name, qt_resource_data)
+
+qInitResources()
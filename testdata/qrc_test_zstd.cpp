@@ -0,0 +1,162 @@
+/****************************************************************************
+** Resource object code
+**
+** Created by: github.com/pgaskin/qrc
+**
+** WARNING! All changes made in this file will be lost!
+*****************************************************************************/
+
+static const unsigned char qt_resource_data[] = {
+  // /main.qml
+  0x0,0x0,0x0,0x1c,
+  0x69,
+  0x6d,0x70,0x6f,0x72,0x74,0x20,0x51,0x74,0x51,0x75,0x69,0x63,0x6b,0x20,0x32,0x2e,
+  0x30,0xa,0xa,0x49,0x74,0x65,0x6d,0x20,0x7b,0x7d,0xa,
+    // /zstd.txt
+  0x0,0x0,0x0,0x18,
+  0x28,
+  0xb5,0x2f,0xfd,0x4,0x0,0x5d,0x0,0x0,0x20,0x7a,0x73,0x74,0x64,0x1,0x54,0x4,
+  0x2,0x28,0x79,0xef,0xde,0x3b,0xa3,
+    // /i18n/hello.txt
+  0x0,0x0,0x0,0x5,
+  0x68,
+  0x65,0x6c,0x6c,0x6f,
+    // /i18n/hello.txt
+  0x0,0x0,0x0,0x7,
+  0x62,
+  0x6f,0x6e,0x6a,0x6f,0x75,0x72,
+    // /img/icon.svg
+  0x0,0x0,0x0,0x15,
+  0x0,
+  0x0,0x0,0x78,0x78,0xda,0xb2,0x29,0x2e,0x4b,0xd7,0xb7,0xa3,0x3f,0x9,0x18,0x0,
+  0x5c,0x13,0x27,0x75,
+  
+};
+
+static const unsigned char qt_resource_name[] = {
+  // img
+  0x0,0x3,
+  0x0,0x0,0x70,0x37,
+  0x0,0x69,
+  0x0,0x6d,0x0,0x67,
+    // i18n
+  0x0,0x4,
+  0x0,0x6,0xc4,0xee,
+  0x0,0x69,
+  0x0,0x31,0x0,0x38,0x0,0x6e,
+    // main.qml
+  0x0,0x8,
+  0x8,0x1,0x5a,0x5c,
+  0x0,0x6d,
+  0x0,0x61,0x0,0x69,0x0,0x6e,0x0,0x2e,0x0,0x71,0x0,0x6d,0x0,0x6c,
+    // zstd.txt
+  0x0,0x8,
+  0xa,0xa7,0x4b,0xd4,
+  0x0,0x7a,
+  0x0,0x73,0x0,0x74,0x0,0x64,0x0,0x2e,0x0,0x74,0x0,0x78,0x0,0x74,
+    // hello.txt
+  0x0,0x9,
+  0x3,0x32,0x86,0x74,
+  0x0,0x68,
+  0x0,0x65,0x0,0x6c,0x0,0x6c,0x0,0x6f,0x0,0x2e,0x0,0x74,0x0,0x78,0x0,0x74,
+    // icon.svg
+  0x0,0x8,
+  0xa,0x61,0x57,0x27,
+  0x0,0x69,
+  0x0,0x63,0x0,0x6f,0x0,0x6e,0x0,0x2e,0x0,0x73,0x0,0x76,0x0,0x67,
+  
+};
+
+static const unsigned char qt_resource_struct[] = {
+  // :
+  0x0,0x0,0x0,0x0,0x0,0x2,0x0,0x0,0x0,0x4,0x0,0x0,0x0,0x1,
+0x0,0x0,0x0,0x0,0x0,0x0,0x0,0x0,
+  // :/img
+  0x0,0x0,0x0,0x0,0x0,0x2,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x7,
+0x0,0x0,0x0,0x0,0x0,0x0,0x0,0x0,
+  // :/i18n
+  0x0,0x0,0x0,0xc,0x0,0x2,0x0,0x0,0x0,0x2,0x0,0x0,0x0,0x5,
+0x0,0x0,0x0,0x0,0x0,0x0,0x0,0x0,
+  // :/main.qml
+  0x0,0x0,0x0,0x1a,0x0,0x0,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x0,
+0x0,0x0,0x1,0x74,0x87,0x6e,0x80,0x0,
+  // :/zstd.txt
+  0x0,0x0,0x0,0x30,0x0,0x4,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x20,
+0x0,0x0,0x0,0x0,0x0,0x0,0x0,0x0,
+  // :/i18n/hello.txt
+  0x0,0x0,0x0,0x46,0x0,0x0,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x3c,
+0x0,0x0,0x1,0x74,0x87,0x6e,0x80,0x0,
+  // :/i18n/hello.txt [38::37[
+  0x0,0x0,0x0,0x46,0x0,0x0,0x0,0x26,0x0,0x25,0x0,0x0,0x0,0x45,
+0x0,0x0,0x1,0x74,0x87,0x6e,0x80,0x0,
+  // :/img/icon.svg
+  0x0,0x0,0x0,0x5e,0x0,0x1,0x0,0x0,0x0,0x1,0x0,0x0,0x0,0x50,
+0x0,0x0,0x1,0x74,0x87,0x6e,0x80,0x0,
+
+};
+
+#ifdef QT_NAMESPACE
+#  define QT_RCC_PREPEND_NAMESPACE(name) ::QT_NAMESPACE::name
+#  define QT_RCC_MANGLE_NAMESPACE0(x) x
+#  define QT_RCC_MANGLE_NAMESPACE1(a, b) a##_##b
+#  define QT_RCC_MANGLE_NAMESPACE2(a, b) QT_RCC_MANGLE_NAMESPACE1(a,b)
+#  define QT_RCC_MANGLE_NAMESPACE(name) QT_RCC_MANGLE_NAMESPACE2( \
+        QT_RCC_MANGLE_NAMESPACE0(name), QT_RCC_MANGLE_NAMESPACE0(QT_NAMESPACE))
+#else
+#   define QT_RCC_PREPEND_NAMESPACE(name) name
+#   define QT_RCC_MANGLE_NAMESPACE(name) name
+#endif
+
+#ifdef QT_NAMESPACE
+namespace QT_NAMESPACE {
+#endif
+
+bool qRegisterResourceData(int, const unsigned char *, const unsigned char *, const unsigned char *);
+bool qUnregisterResourceData(int, const unsigned char *, const unsigned char *, const unsigned char *);
+
+#if defined(__ELF__) || defined(__APPLE__)
+static inline unsigned char qResourceFeatureZstd()
+{
+    extern const unsigned char qt_resourceFeatureZstd;
+    return qt_resourceFeatureZstd;
+}
+static inline unsigned char qResourceFeatureZlib()
+{
+    extern const unsigned char qt_resourceFeatureZlib;
+    return qt_resourceFeatureZlib;
+}
+#else
+unsigned char qResourceFeatureZstd();
+unsigned char qResourceFeatureZlib();
+#endif
+
+#ifdef QT_NAMESPACE
+}
+#endif
+
+int QT_RCC_MANGLE_NAMESPACE(qInitResources_test)();
+int QT_RCC_MANGLE_NAMESPACE(qInitResources_test)()
+{
+    int version = 3;
+    QT_RCC_PREPEND_NAMESPACE(qRegisterResourceData)
+        (version, qt_resource_struct, qt_resource_name, qt_resource_data);
+    return 1;
+}
+
+int QT_RCC_MANGLE_NAMESPACE(qCleanupResources_test)();
+int QT_RCC_MANGLE_NAMESPACE(qCleanupResources_test)()
+{
+    int version = 3;
+    version += QT_RCC_PREPEND_NAMESPACE(qResourceFeatureZstd());
+    version += QT_RCC_PREPEND_NAMESPACE(qResourceFeatureZlib());
+    QT_RCC_PREPEND_NAMESPACE(qUnregisterResourceData)
+       (version, qt_resource_struct, qt_resource_name, qt_resource_data);
+    return 1;
+}
+
+namespace {
+   struct initializer {
+       initializer() { QT_RCC_MANGLE_NAMESPACE(qInitResources_test)(); }
+       ~initializer() { QT_RCC_MANGLE_NAMESPACE(qCleanupResources_test)(); }
+   } dummy;
+}
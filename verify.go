@@ -0,0 +1,283 @@
+package qrc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// VerifyProblem is a structural problem found by Reader.Verify. It is the same
+// as Error, so the underlying error can be checked with errors.Is.
+type VerifyProblem = Error
+
+// Verify checks the structure of the entire tree and the data for every file,
+// and returns all problems found. Nested RCC files are not checked. If
+// ReaderOptions.MaxDepth or ReaderOptions.MaxNodes is exceeded, it is reported
+// as a problem and the affected part of the tree is not checked.
+func (r *Reader) Verify() []*VerifyProblem {
+	v := &verifier{
+		r:     r,
+		nodes: map[uint32]string{0: ""},
+		blobs: map[uint32]*verifyBlob{},
+	}
+	v.node("", 0, r.root)
+	if !r.root.IsDir() {
+		v.problem("tree", r.treeOffset, "", "root node is not a directory")
+	} else {
+		v.dir("", 0, r.root, nil)
+	}
+	v.overlaps()
+	return v.problems
+}
+
+type verifier struct {
+	r        *Reader
+	nodes    map[uint32]string      // node index -> path of the first entry there
+	blobs    map[uint32]*verifyBlob // data offset -> blob
+	problems []*VerifyProblem
+	count    int  // nodes read
+	stop     bool // MaxNodes exceeded
+}
+
+type verifyBlob struct {
+	path  string
+	flags NodeFlag
+	start int64
+	end   int64 // -1 if the size couldn't be read
+}
+
+type verifyKey struct {
+	name     string
+	country  Country
+	language Language
+}
+
+func (v *verifier) problem(region string, offset int64, path string, format string, a ...interface{}) {
+	v.problems = append(v.problems, &VerifyProblem{
+		Region: region,
+		Offset: offset,
+		Path:   path,
+		Err:    fmt.Errorf(format, a...),
+	})
+}
+
+// verifyErr returns the underlying error of the *Error in err, if any, since
+// the problem already has the region, offset, and path.
+func verifyErr(err error) error {
+	var qe *Error
+	if errors.As(err, &qe) {
+		return qe.Err
+	}
+	return err
+}
+
+func (v *verifier) nodeOffset(idx uint32) int64 {
+	return v.r.treeOffset + int64(idx)*nodeSize(v.r.format)
+}
+
+// node checks the flags of a node.
+func (v *verifier) node(path string, idx uint32, n *Node) {
+	if n.Flags.Has(NodeFlagCompressedZstd) && v.r.format < 3 {
+		v.problem("tree", v.nodeOffset(idx), path, "%w: %s not supported by format version %d", ErrInvalidFlags, n.Flags, v.r.format)
+	}
+	if n.IsDir() && n.Flags&(NodeFlagCompressed|NodeFlagCompressedZstd) != 0 {
+		v.problem("tree", v.nodeOffset(idx), path, "%w: %s not valid for a directory", ErrInvalidFlags, n.Flags)
+	}
+}
+
+// dir checks the children of a directory, then recurses into them.
+func (v *verifier) dir(path string, idx uint32, n *Node, ancestors []uint32) {
+	if n.ChildCount == 0 || v.stop {
+		return
+	}
+	if max := v.r.opt.MaxDepth; max >= 0 && len(ancestors)+1 > max {
+		v.problem("tree", v.nodeOffset(idx), path, "%w (%d)", ErrMaxDepth, max)
+		return
+	}
+	if max := v.r.opt.MaxNodes; max >= 0 && int64(v.count)+int64(n.ChildCount) > int64(max) {
+		v.problem("tree", v.nodeOffset(idx), path, "%d children after %d nodes: %w (%d)", n.ChildCount, v.count, ErrMaxNodes, max)
+		v.stop = true
+		return
+	}
+	v.count += int(n.ChildCount)
+
+	start, end := uint64(n.ChildOffset), uint64(n.ChildOffset)+uint64(n.ChildCount)
+	if v.r.treeSize != -1 && int64(end)*nodeSize(v.r.format) > v.r.treeSize {
+		v.problem("tree", v.nodeOffset(idx), path, "child range [%d, %d) outside the tree (%d nodes)", start, end, v.r.treeSize/nodeSize(v.r.format))
+		return
+	}
+	if end > 1<<32 {
+		v.problem("tree", v.nodeOffset(idx), path, "child range [%d, %d) overflows", start, end)
+		return
+	}
+	ancestors = append(ancestors[:len(ancestors):len(ancestors)], idx)
+
+	var (
+		children []*Node
+		paths    []string
+		indexes  []uint32
+		prevHash uint32
+		seen     = map[verifyKey]bool{}
+	)
+	for i := start; i < end; i++ {
+		ci := uint32(i)
+		for _, a := range ancestors {
+			if ci == a {
+				v.problem("tree", v.nodeOffset(idx), path, "child range [%d, %d) contains ancestor node %d (cycle)", start, end, a)
+				return
+			}
+		}
+		if p, ok := v.nodes[ci]; ok {
+			v.problem("tree", v.nodeOffset(idx), path, "child range [%d, %d) overlaps node %d already used by /%s", start, end, i, p)
+			return
+		}
+
+		cpath := strings.TrimLeft(fmt.Sprintf("%s/<node %d>", path, ci), "/")
+		v.nodes[ci] = cpath
+
+		c, err := ParseNode(io.NewSectionReader(v.r.tree(), int64(ci)*nodeSize(v.r.format), nodeSize(v.r.format)), v.r.format)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				// the rest of the range is past the end too
+				v.problem("tree", v.nodeOffset(ci), cpath, "child range [%d, %d) runs past the end of the tree: %w", start, end, err)
+				break
+			}
+			v.problem("tree", v.nodeOffset(ci), cpath, "parse node: %w", err)
+			continue
+		}
+
+		name, err := v.r.name(c)
+		if err != nil {
+			v.problem("names", v.r.namesOffset+int64(c.NameOffset), cpath, "read name: %w", err)
+		} else {
+			cpath = strings.TrimLeft(path+"/"+name, "/")
+			v.nodes[ci] = cpath
+			if v.r.namesSize != -1 {
+				if x := int64(c.NameOffset) + 6 + int64(len(utf16.Encode([]rune(name))))*2 - v.r.namesSize; x > 0 {
+					v.problem("names", v.r.namesOffset+int64(c.NameOffset), cpath, "name runs past the end of the names region by %d bytes", x)
+				}
+			}
+
+			var buf [4]byte
+			if _, err := v.r.names().ReadAt(buf[:], int64(c.NameOffset)+2); err != nil {
+				v.problem("names", v.r.namesOffset+int64(c.NameOffset)+2, cpath, "read name hash: %w", err)
+			} else {
+				hash := binary.BigEndian.Uint32(buf[:])
+				if exp := qtHash(name); hash != exp {
+					v.problem("names", v.r.namesOffset+int64(c.NameOffset)+2, cpath, "name hash %#x doesn't match expected %#x", hash, exp)
+				}
+				if hash < prevHash {
+					v.problem("tree", v.nodeOffset(ci), cpath, "children not sorted by name hash (%#x after %#x)", hash, prevHash)
+				}
+				prevHash = hash
+			}
+
+			k := verifyKey{name, c.Country, c.Language}
+			if seen[k] {
+				v.problem("tree", v.nodeOffset(ci), cpath, "duplicate name with identical constraints (%s, %s)", c.Country, c.Language)
+			}
+			seen[k] = true
+		}
+
+		v.node(cpath, ci, c)
+		children = append(children, c)
+		paths = append(paths, cpath)
+		indexes = append(indexes, ci)
+	}
+
+	for i, c := range children {
+		if v.stop {
+			return
+		}
+		if c.IsDir() {
+			v.dir(paths[i], indexes[i], c, ancestors)
+		} else {
+			v.file(paths[i], c)
+		}
+	}
+}
+
+// file checks the data for a file.
+func (v *verifier) file(path string, n *Node) {
+	if b, ok := v.blobs[n.DataOffset]; ok {
+		if b.flags != n.Flags {
+			v.problem("data", v.r.dataOffset+int64(n.DataOffset), path, "data shared with /%s, but flags %s don't match %s", b.path, n.Flags, b.flags)
+		}
+		return
+	}
+	b := &verifyBlob{
+		path:  path,
+		flags: n.Flags,
+		start: int64(n.DataOffset),
+		end:   -1,
+	}
+	v.blobs[n.DataOffset] = b
+
+	sz, err := n.fileSize(v.r.data())
+	if err != nil {
+		v.problem("data", v.r.dataOffset+b.start, path, "%w", err)
+		return
+	}
+	b.end = b.start + 4 + sz
+
+	if v.r.dataSize != -1 && b.end > v.r.dataSize {
+		v.problem("data", v.r.dataOffset+b.start, path, "data runs past the end of the data region by %d bytes", b.end-v.r.dataSize)
+		return
+	}
+
+	if rn, err := io.Copy(ioutil.Discard, io.NewSectionReader(v.r.data(), n.fileDataOffset(), sz)); err != nil {
+		v.problem("data", v.r.dataOffset+b.start, path, "read %d bytes of data: %v", sz, err)
+		return
+	} else if x := sz - rn; x != 0 {
+		v.problem("data", v.r.dataOffset+b.start, path, "data runs past the end of the input by %d bytes", x)
+		return
+	}
+
+	rc, err := (&ReaderEntry{p: path, n: n, r: v.r}).Open()
+	if err != nil {
+		v.problem("data", v.r.dataOffset+b.start, path, "%w", verifyErr(err))
+		return
+	}
+	defer rc.Close()
+
+	usz, err := io.Copy(ioutil.Discard, rc)
+	if err != nil {
+		v.problem("data", v.r.dataOffset+b.start, path, "decompress: %w", verifyErr(err))
+		return
+	}
+	if n.Flags.Has(NodeFlagCompressed) {
+		var buf [4]byte
+		if _, err := v.r.data().ReadAt(buf[:], n.fileDataOffset()); err == nil {
+			if hsz := binary.BigEndian.Uint32(buf[:]); int64(hsz) != usz {
+				v.problem("data", v.r.dataOffset+b.start, path, "qCompress header size %d doesn't match actual size %d", hsz, usz)
+			}
+		}
+	}
+}
+
+// overlaps checks for data which overlaps other data.
+func (v *verifier) overlaps() {
+	var bs []*verifyBlob
+	for _, b := range v.blobs {
+		if b.end != -1 {
+			bs = append(bs, b)
+		}
+	}
+	sort.Slice(bs, func(i, j int) bool {
+		return bs[i].start < bs[j].start
+	})
+	var last *verifyBlob
+	for _, b := range bs {
+		if last != nil && b.start < last.end {
+			v.problem("data", v.r.dataOffset+b.start, b.path, "data overlaps data for /%s by %d bytes", last.path, last.end-b.start)
+		}
+		if last == nil || b.end > last.end {
+			last = b
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package qrc
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	for _, format := range []int{1, 2, 3} {
+		if p := testReader(t, testWriter(t), format).Verify(); len(p) != 0 {
+			t.Errorf("format %d: expected no problems, got %v", format, p)
+		}
+	}
+
+	var zb bytes.Buffer
+	zw := zlib.NewWriter(&zb)
+	zw.Write([]byte("hello"))
+	zw.Close()
+
+	names := testAppend(t, nil, func(b []byte) ([]byte, error) { return AppendName(b, "a") })
+	names = testAppend(t, names, func(b []byte) ([]byte, error) { return AppendName(b, "b") })
+	names = testAppend(t, names, func(b []byte) ([]byte, error) { return AppendName(b, "dir") })
+	const nameA, nameB, nameDir, nameBad = 0, 8, 16, 28
+	names = append(names, 0, 1, 0, 0, 0, 0, 0, 'x') // "x" with an incorrect hash
+
+	var data []byte
+	var dataHello, dataNested, dataZlib, dataZlibBad, dataGarbage, dataEnd uint32
+	for _, d := range []struct {
+		off *uint32
+		fn  func([]byte) ([]byte, error)
+	}{
+		{&dataHello, func(b []byte) ([]byte, error) { return AppendData(b, []byte("hello")) }},
+		{&dataNested, func(b []byte) ([]byte, error) { return AppendData(b, []byte{0, 0, 0, 1, 'x'}) }},
+		{&dataZlib, func(b []byte) ([]byte, error) { return AppendZlibData(b, zb.Bytes(), 5) }},
+		{&dataZlibBad, func(b []byte) ([]byte, error) { return AppendZlibData(b, zb.Bytes(), 6) }},
+		{&dataGarbage, func(b []byte) ([]byte, error) { return AppendData(b, []byte("garbage")) }},
+		{&dataEnd, func(b []byte) ([]byte, error) { return b, nil }},
+	} {
+		*d.off = uint32(len(data))
+		data = testAppend(t, data, d.fn)
+	}
+	data = append(data, 0, 0, 1, 0) // 256 bytes past the end
+
+	root := func(count, offset uint32) Node {
+		return Node{Flags: NodeFlagDirectory, ChildCount: count, ChildOffset: offset}
+	}
+	dir := func(name, count, offset uint32) Node {
+		return Node{NameOffset: name, Flags: NodeFlagDirectory, ChildCount: count, ChildOffset: offset}
+	}
+	dirFlags := func(name uint32, flags NodeFlag) Node {
+		return Node{NameOffset: name, Flags: NodeFlagDirectory | flags}
+	}
+	file := func(name uint32, flags NodeFlag, data uint32) Node {
+		return Node{NameOffset: name, Flags: flags, DataOffset: data}
+	}
+
+	for _, tc := range []struct {
+		what   string
+		format int
+		tree   []Node
+		exp    []string
+	}{
+		{"valid", 2, []Node{root(2, 1), file(nameA, NodeFlagNone, dataHello), dir(nameDir, 1, 3), file(nameB, NodeFlagCompressed, dataZlib)}, nil},
+		{"shared data", 2, []Node{root(2, 1), file(nameA, NodeFlagNone, dataHello), file(nameB, NodeFlagNone, dataHello)}, nil},
+		{"cycle", 2, []Node{root(1, 1), dir(nameDir, 1, 0)}, []string{"tree at 0x2a (/dir): child range [0, 1) contains ancestor node 0 (cycle)"}},
+		{"overlap", 2, []Node{root(2, 1), file(nameA, NodeFlagNone, dataHello), dir(nameDir, 1, 1)}, []string{"tree at 0x40 (/dir): child range [1, 2) overlaps node 1 already used by /a"}},
+		{"outside", 2, []Node{root(1, 1), dir(nameDir, 5, 2)}, []string{"(/dir): child range [2, 7) outside the tree (2 nodes)"}},
+		{"unsorted", 2, []Node{root(2, 1), file(nameB, NodeFlagNone, dataHello), file(nameA, NodeFlagNone, dataHello)}, []string{"tree at 0x40 (/a): children not sorted by name hash (0x61 after 0x62)"}},
+		{"hash", 2, []Node{root(1, 1), file(nameBad, NodeFlagNone, dataHello)}, []string{"(/x): name hash 0x0 doesn't match expected 0x78"}},
+		{"duplicate", 2, []Node{root(2, 1), file(nameA, NodeFlagNone, dataHello), file(nameA, NodeFlagNone, dataZlib)}, []string{"tree at 0x40 (/a): duplicate name with identical constraints"}},
+		{"data overlap", 2, []Node{root(2, 1), file(nameA, NodeFlagNone, dataNested), file(nameB, NodeFlagNone, dataNested+4)}, []string{"(/b): data overlaps data for /a by 5 bytes"}},
+		{"shared flags", 2, []Node{root(2, 1), file(nameA, NodeFlagNone, dataZlib), file(nameB, NodeFlagCompressed, dataZlib)}, []string{"(/b): data shared with /a, but flags None|Compressed don't match None"}},
+		{"qCompress size", 2, []Node{root(1, 1), file(nameA, NodeFlagCompressed, dataZlibBad)}, []string{"(/a): qCompress header size 6 doesn't match actual size 5"}},
+		{"zlib", 2, []Node{root(1, 1), file(nameA, NodeFlagCompressed, dataGarbage)}, []string{"(/a): open zlib reader"}},
+		{"zstd", 3, []Node{root(1, 1), file(nameA, NodeFlagCompressedZstd, dataGarbage)}, []string{"(/a): decompress: invalid input"}},
+		{"zstd format", 2, []Node{root(1, 1), file(nameA, NodeFlagCompressedZstd, dataGarbage)}, []string{"(/a): invalid flags: None|CompressedZstd not supported by format version 2", "(/a): decompress: invalid input"}},
+		{"dir flags", 2, []Node{root(1, 1), dirFlags(nameDir, NodeFlagCompressed)}, []string{"tree at 0x2a (/dir): invalid flags: None|Compressed|Directory not valid for a directory"}},
+		{"past end", 2, []Node{root(1, 1), file(nameA, NodeFlagNone, dataEnd)}, []string{"(/a): data runs past the end of the data region by 256 bytes"}},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			r, err := NewReaderFromRCC(bytes.NewReader(testRCC(t, tc.format, tc.tree, names, data)))
+			if err != nil {
+				t.Fatalf("read rcc: %v", err)
+			}
+			p := r.Verify()
+			if len(p) != len(tc.exp) {
+				t.Fatalf("expected %d problems, got %d: %v", len(tc.exp), len(p), p)
+			}
+			for i := range p {
+				if !strings.Contains(p[i].Error(), tc.exp[i]) {
+					t.Errorf("expected problem %d to contain %q, got %q", i, tc.exp[i], p[i].Error())
+				}
+			}
+		})
+	}
+}
+
+// testRCC builds a RCC file with the tree, then the names, then the data.
+func testRCC(t testing.TB, format int, tree []Node, names, data []byte) []byte {
+	t.Helper()
+	h := RCCHeader{
+		Magic:         RCCHeaderMagic,
+		FormatVersion: int32(format),
+		TreeOffset:    int32(rccHeaderSize(format)),
+	}
+	h.NamesOffset = h.TreeOffset + int32(len(tree))*int32(nodeSize(format))
+	h.DataOffset = h.NamesOffset + int32(len(names))
+	b := testAppend(t, nil, h.AppendBinary)
+	for _, n := range tree {
+		n.Format = format
+		if format == 2 {
+			n.Format = 3 // same encoding, but allows zstd flags
+		}
+		b = testAppend(t, b, n.AppendBinary)
+	}
+	b = append(b, names...)
+	b = append(b, data...)
+	return b
+}
+
+func testAppend(t testing.TB, b []byte, fn func([]byte) ([]byte, error)) []byte {
+	t.Helper()
+	b, err := fn(b)
+	if err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	return b
+}
+
+func TestVerifyLimits(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testWriter(t).WriteRCC(&buf, 2); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+	h, err := ParseRCCHeader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("parse header: %v", err)
+	}
+
+	// the tree is last, so the size isn't known
+	huge := append([]byte(nil), buf.Bytes()...)
+	binary.BigEndian.PutUint32(huge[h.TreeOffset+6:], 0xFFFFFFF0)
+
+	for _, tc := range []struct {
+		what string
+		rcc  []byte
+		opt  ReaderOptions
+		n    int
+		exp  string
+		err  error
+	}{
+		{"max nodes", huge, ReaderOptions{}, 1, "4294967280 children after 0 nodes", ErrMaxNodes},
+		{"past end", huge, ReaderOptions{MaxNodes: -1}, 4, "child range [1, 4294967281) runs past the end of the tree", io.EOF},
+		{"max depth", buf.Bytes(), ReaderOptions{MaxDepth: 1}, 2, "(/img)", ErrMaxDepth},
+	} {
+		opt := tc.opt
+		r, err := NewReaderFromRCCWithOptions(struct{ io.ReaderAt }{bytes.NewReader(tc.rcc)}, &opt) // hide the size
+		if err != nil {
+			t.Fatalf("%s: read rcc: %v", tc.what, err)
+		}
+		p := r.Verify()
+		if len(p) != tc.n {
+			t.Fatalf("%s: expected %d problems, got %d: %v", tc.what, tc.n, len(p), p)
+		}
+		var found bool
+		for _, x := range p {
+			found = found || strings.Contains(x.Error(), tc.exp) && errors.Is(x, tc.err)
+		}
+		if !found {
+			t.Errorf("%s: expected a problem containing %q and matching %v, got %v", tc.what, tc.exp, tc.err, p)
+		}
+	}
+}
+
+func TestVerifyErr(t *testing.T) {
+	for _, err := range []error{
+		io.ErrUnexpectedEOF,
+		&Error{Region: "data", Err: io.ErrUnexpectedEOF},
+		fmt.Errorf("open: %w", &Error{Region: "data", Err: io.ErrUnexpectedEOF}),
+	} {
+		if x := verifyErr(err); x != io.ErrUnexpectedEOF {
+			t.Errorf("%v: expected the underlying error, got %v", err, x)
+		}
+	}
+}
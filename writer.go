@@ -0,0 +1,443 @@
+package qrc
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Writer builds a Qt resource tree in memory, which can then be written in the
+// compiled format. It is not thread-safe.
+type Writer struct {
+	root *writerNode
+}
+
+type writerNode struct {
+	name     string
+	dir      bool
+	children []*writerNode
+
+	country  Country
+	language Language
+	blob     *writerBlob
+
+	modTime time.Time
+}
+
+// writerBlob is the data for a file as it will be stored in the resource (i.e.
+// after compression, but without the uint32 size header).
+type writerBlob struct {
+	flags NodeFlag
+	data  []byte
+}
+
+// writerLayout is the layout of a Writer's tree in the compiled format.
+type writerLayout struct {
+	tree  []layoutNode
+	names []layoutName
+	data  []layoutData
+	flags NodeFlag // for the RCC header
+}
+
+type layoutNode struct {
+	path string
+	Node
+}
+
+type layoutName struct {
+	name string
+	hash uint32
+	u16  []uint16
+}
+
+type layoutData struct {
+	path string
+	blob *writerBlob
+}
+
+// NewWriter creates a new Writer with an empty root directory.
+func NewWriter() *Writer {
+	return &Writer{
+		root: &writerNode{dir: true},
+	}
+}
+
+// Mkdir creates a directory (and any parent directories), setting the
+// modification time of the last path component. If the directory already
+// exists, only the modification time is updated.
+func (w *Writer) Mkdir(path string, modTime time.Time) error {
+	d, err := w.mkdirAll(path)
+	if err != nil {
+		return err
+	}
+	d.modTime = modTime
+	return nil
+}
+
+// Add adds a file with the provided (uncompressed) contents, creating any
+// parent directories as needed. Unconstrained files should use
+// CountryAnyCountry and LanguageC (this is what rcc does). If a file with the
+// same name and constraints already exists, an error is returned.
+func (w *Writer) Add(path string, country Country, language Language, modTime time.Time, data []byte) error {
+	return w.AddRaw(path, country, language, modTime, NodeFlagNone, data)
+}
+
+// AddRaw is like Add, but the data is as stored in the resource. If flags
+// contains NodeFlagCompressed, data must include the 4-byte qCompress size
+// header. The data is not validated.
+func (w *Writer) AddRaw(path string, country Country, language Language, modTime time.Time, flags NodeFlag, data []byte) error {
+	if err := flags.Valid(); err != nil {
+		return fmt.Errorf("add %q: invalid flags: %w", path, err)
+	}
+	if flags.Has(NodeFlagDirectory) {
+		return fmt.Errorf("add %q: flags must not contain Directory", path)
+	}
+	return w.add(path, &writerNode{
+		country:  country,
+		language: language,
+		modTime:  modTime,
+		blob: &writerBlob{
+			flags: flags,
+			data:  data,
+		},
+	}, ConflictError)
+}
+
+// add adds a file node, setting its name from the path.
+func (w *Writer) add(path string, n *writerNode, conflict ConflictPolicy) error {
+	dir, name, err := w.parent(path)
+	if err != nil {
+		return err
+	}
+	n.name = name
+
+	for i, c := range dir.children {
+		if c.name != name {
+			continue
+		}
+		if c.dir {
+			return fmt.Errorf("add %q: %w", path, ErrIsDir)
+		}
+		if c.country == n.country && c.language == n.language {
+			switch conflict {
+			case ConflictKeepFirst:
+				return nil
+			case ConflictKeepLast:
+				dir.children[i] = n
+				return nil
+			default:
+				return fmt.Errorf("add %q: file with constraints %s/%s already exists", path, n.country, n.language)
+			}
+		}
+	}
+
+	dir.children = append(dir.children, n)
+	return nil
+}
+
+// WriteRCC writes the tree as a RCC file with the specified format version.
+func (w *Writer) WriteRCC(out io.Writer, formatVersion int) error {
+	l, err := w.layout(formatVersion)
+	if err != nil {
+		return err
+	}
+	data, err := l.dataBytes()
+	if err != nil {
+		return err
+	}
+	names, err := l.namesBytes()
+	if err != nil {
+		return err
+	}
+	tree, err := l.treeBytes()
+	if err != nil {
+		return err
+	}
+
+	h := RCCHeader{
+		Magic:         RCCHeaderMagic,
+		FormatVersion: int32(formatVersion),
+	}
+	if formatVersion >= 3 {
+		h.OverallFlags = int32(l.flags)
+	}
+	h.DataOffset = int32(rccHeaderSize(formatVersion))
+	h.NamesOffset = h.DataOffset + int32(len(data))
+	h.TreeOffset = h.NamesOffset + int32(len(names))
+
+	b, err := h.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	for _, x := range [][]byte{b, data, names, tree} {
+		if _, err := out.Write(x); err != nil {
+			return fmt.Errorf("write rcc: %w", err)
+		}
+	}
+	return nil
+}
+
+// layout lays out the tree, names, and data for the specified format version
+// in the same order as rcc. The children of each directory are sorted by name
+// hash so Qt can do a binary search on them, and directories are visited in
+// stack order. Names and data are deduplicated.
+func (w *Writer) layout(format int) (*writerLayout, error) {
+	if format < 1 || format > 3 {
+		return nil, fmt.Errorf("unsupported format version %d", format)
+	}
+
+	var l writerLayout
+	var namesSize, dataSize uint32
+	nameOffset := map[string]uint32{}
+	dataOffset := map[*writerBlob]uint32{}
+
+	name := func(n string) (uint32, error) {
+		if o, ok := nameOffset[n]; ok {
+			return o, nil
+		}
+		u := utf16.Encode([]rune(n))
+		if len(u) > 0xFFFF {
+			return 0, fmt.Errorf("name %q too long", n)
+		}
+		o := namesSize
+		l.names = append(l.names, layoutName{
+			name: n,
+			hash: qtHash(n),
+			u16:  u,
+		})
+		namesSize += 2 + 4 + uint32(len(u))*2
+		nameOffset[n] = o
+		return o, nil
+	}
+
+	data := func(path string, b *writerBlob) (uint32, error) {
+		if o, ok := dataOffset[b]; ok {
+			return o, nil
+		}
+		if uint64(dataSize)+4+uint64(len(b.data)) > 1<<31-1 {
+			return 0, fmt.Errorf("data too large")
+		}
+		o := dataSize
+		l.data = append(l.data, layoutData{
+			path: path,
+			blob: b,
+		})
+		dataSize += 4 + uint32(len(b.data))
+		dataOffset[b] = o
+		return o, nil
+	}
+
+	type entry struct {
+		*writerNode
+		path        string
+		childOffset uint32
+	}
+
+	// assign child offsets
+	nodes := []*entry{{writerNode: w.root}}
+	stack := []*entry{nodes[0]}
+	for len(stack) != 0 {
+		d := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		d.childOffset = uint32(len(nodes))
+		for _, c := range sortedChildren(d.writerNode) {
+			e := &entry{
+				writerNode: c,
+				path:       d.path + "/" + c.name,
+			}
+			nodes = append(nodes, e)
+			if c.dir {
+				stack = append(stack, e)
+			}
+		}
+	}
+
+	for i, e := range nodes {
+		var n Node
+		n.Format = format
+		if i != 0 {
+			o, err := name(e.name)
+			if err != nil {
+				return nil, err
+			}
+			n.NameOffset = o
+		}
+		if e.dir {
+			n.Flags = NodeFlagDirectory
+			n.ChildCount = uint32(len(e.children))
+			n.ChildOffset = e.childOffset
+		} else {
+			if e.blob.flags.Has(NodeFlagCompressedZstd) && format < 3 {
+				return nil, fmt.Errorf("zstd compression requires format version 3")
+			}
+			o, err := data(e.path, e.blob)
+			if err != nil {
+				return nil, err
+			}
+			n.Flags = e.blob.flags
+			n.Country = e.country
+			n.Language = e.language
+			n.DataOffset = o
+			l.flags |= e.blob.flags & (NodeFlagCompressed | NodeFlagCompressedZstd)
+		}
+		if !e.modTime.IsZero() && e.modTime.Unix() > 0 {
+			n.Modified = uint64(e.modTime.UnixNano() / int64(time.Millisecond))
+		}
+		l.tree = append(l.tree, layoutNode{
+			path: e.path,
+			Node: n,
+		})
+	}
+
+	return &l, nil
+}
+
+// treeBytes encodes the tree nodes.
+func (l *writerLayout) treeBytes() ([]byte, error) {
+	var b []byte
+	for _, n := range l.tree {
+		var err error
+		if b, err = n.AppendBinary(b); err != nil {
+			return nil, fmt.Errorf("encode node %q: %w", n.path, err)
+		}
+	}
+	return b, nil
+}
+
+// namesBytes encodes the names.
+func (l *writerLayout) namesBytes() ([]byte, error) {
+	var b []byte
+	for _, n := range l.names {
+		var err error
+		if b, err = AppendName(b, n.name); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// dataBytes encodes the data.
+func (l *writerLayout) dataBytes() ([]byte, error) {
+	var b []byte
+	for _, d := range l.data {
+		var err error
+		if b, err = AppendData(b, d.blob.data); err != nil {
+			return nil, fmt.Errorf("encode data for %q: %w", d.path, err)
+		}
+	}
+	return b, nil
+}
+
+// walk calls fn for each node in the tree (except the root) depth-first.
+func (w *Writer) walk(fn func(path string, n *writerNode) error) error {
+	var rec func(path string, d *writerNode) error
+	rec = func(path string, d *writerNode) error {
+		for _, c := range d.children {
+			p := strings.TrimLeft(path+"/"+c.name, "/")
+			if err := fn(p, c); err != nil {
+				return err
+			}
+			if c.dir {
+				if err := rec(p, c); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return rec("", w.root)
+}
+
+// parent resolves the parent directory for a file, creating it if necessary.
+func (w *Writer) parent(path string) (*writerNode, string, error) {
+	s, err := splitPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(s) == 0 {
+		return nil, "", fmt.Errorf("add %q: path must not be empty", path)
+	}
+	d, err := w.mkdirAll(strings.Join(s[:len(s)-1], "/"))
+	if err != nil {
+		return nil, "", err
+	}
+	return d, s[len(s)-1], nil
+}
+
+// mkdirAll resolves a directory, creating it if necessary.
+func (w *Writer) mkdirAll(path string) (*writerNode, error) {
+	s, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	d := w.root
+	for i, x := range s {
+		var nd *writerNode
+		for _, c := range d.children {
+			if c.name == x {
+				if !c.dir {
+					return nil, fmt.Errorf("mkdir %q: %q: %w", path, strings.Join(s[:i+1], "/"), ErrNotDir)
+				}
+				nd = c
+				break
+			}
+		}
+		if nd == nil {
+			nd = &writerNode{name: x, dir: true}
+			d.children = append(d.children, nd)
+		}
+		d = nd
+	}
+	return d, nil
+}
+
+// splitPath splits a slash-separated resource path, ignoring leading and
+// trailing slashes.
+func splitPath(path string) ([]string, error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil, nil
+	}
+	s := strings.Split(path, "/")
+	for _, x := range s {
+		if x == "" || x == "." || x == ".." {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return s, nil
+}
+
+// sortedChildren returns the children of a directory sorted by name hash.
+// Files with the same name are kept together in a stable order.
+func sortedChildren(d *writerNode) []*writerNode {
+	c := make([]*writerNode, len(d.children))
+	copy(c, d.children)
+	sort.SliceStable(c, func(i, j int) bool {
+		if hi, hj := qtHash(c[i].name), qtHash(c[j].name); hi != hj {
+			return hi < hj
+		}
+		if c[i].name != c[j].name {
+			return c[i].name < c[j].name
+		}
+		if c[i].language != c[j].language {
+			return c[i].language < c[j].language
+		}
+		return c[i].country < c[j].country
+	})
+	return c
+}
+
+// qtHash is the hash function used by Qt for resource names (qt_hash).
+func qtHash(s string) uint32 {
+	var h uint32
+	for _, c := range utf16.Encode([]rune(s)) {
+		h = (h << 4) + uint32(c)
+		h ^= (h & 0xf0000000) >> 23
+		h &= 0x0fffffff
+	}
+	return h
+}
@@ -0,0 +1,182 @@
+package qrc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+	mt := time.Unix(1600000000, 0)
+
+	w := NewWriter()
+	for _, f := range []struct {
+		p string
+		c Country
+		l Language
+		d string
+	}{
+		{"a.txt", CountryAnyCountry, LanguageC, "a"},
+		{"dir/b.txt", CountryAnyCountry, LanguageC, "b"},
+		{"dir/b.txt", CountryCanada, LanguageFrench, "b (fr_CA)"},
+		{"dir/sub/c.txt", CountryAnyCountry, LanguageC, ""},
+	} {
+		if err := w.Add(f.p, f.c, f.l, mt, []byte(f.d)); err != nil {
+			t.Fatalf("add %q: %v", f.p, err)
+		}
+	}
+	if err := w.Mkdir("empty", mt); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := w.Add("dir/b.txt", CountryAnyCountry, LanguageC, mt, nil); err == nil {
+		t.Errorf("expected error when adding duplicate file")
+	}
+	if err := w.Add("a.txt/x", CountryAnyCountry, LanguageC, mt, nil); err == nil {
+		t.Errorf("expected error when adding file under a file")
+	}
+	if err := w.Add("dir", CountryAnyCountry, LanguageC, mt, nil); err == nil {
+		t.Errorf("expected error when replacing dir with file")
+	}
+	if err := w.Add("../x", CountryAnyCountry, LanguageC, mt, nil); err == nil {
+		t.Errorf("expected error for invalid path")
+	}
+
+	for format := 1; format <= 3; format++ {
+		var buf bytes.Buffer
+		if err := w.WriteRCC(&buf, format); err != nil {
+			t.Fatalf("format %d: write: %v", format, err)
+		}
+
+		r, err := NewReaderFromRCC(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("format %d: read: %v", format, err)
+		}
+
+		files := map[string]string{}
+		if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if format >= 2 && (!entry.IsDir() || path == "empty") && !entry.ModTime().Equal(mt) {
+				t.Errorf("format %d: %q: incorrect mod time %s", format, path, entry.ModTime())
+			}
+			if entry.IsDir() {
+				files[path+"/"] = ""
+				return nil
+			}
+			rc, err := entry.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			b, err := ioutil.ReadAll(rc)
+			if err != nil {
+				return err
+			}
+			c, l := entry.Constraints()
+			files[FormatConstraints(path, c, l)] = string(b)
+			return nil
+		}, false); err != nil {
+			t.Fatalf("format %d: walk: %v", format, err)
+		}
+
+		exp := map[string]string{
+			"a.txt":     "a",
+			"dir/":      "",
+			"dir/b.txt": "b",
+			"dir/b[country!Canada][language!French].txt": "b (fr_CA)",
+			"dir/sub/":      "",
+			"dir/sub/c.txt": "",
+			"empty/":        "",
+		}
+		if len(files) != len(exp) {
+			t.Errorf("format %d: expected %d files, got %d: %v", format, len(exp), len(files), files)
+		}
+		for k, v := range exp {
+			if x, ok := files[k]; !ok {
+				t.Errorf("format %d: missing %q", format, k)
+			} else if x != v {
+				t.Errorf("format %d: %q: expected %q, got %q", format, k, v, x)
+			}
+		}
+	}
+}
+
+func TestQtHash(t *testing.T) {
+	// values from qt_hash
+	for s, h := range map[string]uint32{
+		"":                      0x00000000,
+		"a":                     0x00000061,
+		"qml":                   0x0000783c,
+		"main.qml":              0x08015a5c,
+		"qtquickcontrols2.conf": 0x081e1666,
+	} {
+		if v := qtHash(s); v != h {
+			t.Errorf("qtHash(%q): expected %#08x, got %#08x", s, h, v)
+		}
+	}
+}
+
+// testReader writes w and opens it with a Reader.
+func testReader(t testing.TB, w *Writer, format int) *Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := w.WriteRCC(&buf, format); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+	r, err := NewReaderFromRCC(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+	return r
+}
+
+// testFiles reads the contents of each file in r (with the constraints added
+// to the name using FormatConstraints).
+func testFiles(t testing.TB, r *Reader) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		b, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return err
+		}
+		c, l := entry.Constraints()
+		files[FormatConstraints(path, c, l)] = string(b)
+		return nil
+	}, false); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	return files
+}
+
+// testFilesEqual checks whether the files in r match exp.
+func testFilesEqual(t testing.TB, r *Reader, exp map[string]string) {
+	t.Helper()
+	files := testFiles(t, r)
+	for k, v := range exp {
+		if x, ok := files[k]; !ok {
+			t.Errorf("missing %q", k)
+		} else if x != v {
+			t.Errorf("%q: expected %q, got %q", k, v, x)
+		}
+	}
+	for k := range files {
+		if _, ok := exp[k]; !ok {
+			t.Errorf("unexpected %q", k)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package qrc
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+)
+
+// FormatConstraints adds the country/language constraints to a filename before
+// the extension in the format used by qrc2zip ('[country!CountryName]' and
+// '[language!LanguageName]'). Constraints which match any country/language are
+// omitted.
+func FormatConstraints(name string, country Country, language Language) string {
+	var c string
+	if country != CountryAnyCountry {
+		c += "[country!" + country.String() + "]"
+	}
+	if language != LanguageAnyLanguage && language != LanguageC {
+		c += "[language!" + language.String() + "]"
+	}
+	if c == "" {
+		return name
+	}
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + c + ext
+}
+
+// ParseConstraints is the inverse of FormatConstraints. If the name does not
+// have a country or language constraint, CountryAnyCountry or LanguageC is
+// returned (this matches the defaults used by rcc). If a constraint is invalid
+// or specified more than once, an error is returned.
+func ParseConstraints(name string) (string, Country, Language, error) {
+	country, language := CountryAnyCountry, LanguageC
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	var seenCountry, seenLanguage bool
+	for strings.HasSuffix(stem, "]") {
+		i := strings.LastIndex(stem, "[")
+		if i == -1 {
+			break
+		}
+		spl := strings.SplitN(stem[i+1:len(stem)-1], "!", 2)
+		if len(spl) != 2 {
+			break
+		}
+		switch spl[0] {
+		case "country":
+			if seenCountry {
+				return "", 0, 0, fmt.Errorf("parse constraints for %q: duplicate country", name)
+			}
+			v, ok := ParseCountry(spl[1])
+			if !ok {
+				return "", 0, 0, fmt.Errorf("parse constraints for %q: unknown country %q", name, spl[1])
+			}
+			country, seenCountry = v, true
+		case "language":
+			if seenLanguage {
+				return "", 0, 0, fmt.Errorf("parse constraints for %q: duplicate language", name)
+			}
+			v, ok := ParseLanguage(spl[1])
+			if !ok {
+				return "", 0, 0, fmt.Errorf("parse constraints for %q: unknown language %q", name, spl[1])
+			}
+			language, seenLanguage = v, true
+		default:
+			return stem + ext, country, language, nil
+		}
+		stem = stem[:i]
+	}
+	return stem + ext, country, language, nil
+}
+
+// NewWriterFromZip creates a Writer from a zip file in the format written by
+// qrc2zip. Country/language constraints are parsed from the filenames with
+// ParseConstraints, and modification times are restored if present.
+func NewWriterFromZip(z *zip.Reader) (*Writer, error) {
+	w := NewWriter()
+	for _, f := range z.File {
+		if err := addZipFile(w, f); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func addZipFile(w *Writer, f *zip.File) error {
+	mt := f.Modified
+	if f.ModifiedDate == 0 && f.ModifiedTime == 0 {
+		mt = time.Time{} // qrc2zip doesn't set it for format 1
+	}
+
+	if f.FileInfo().IsDir() {
+		if err := w.Mkdir(f.Name, mt); err != nil {
+			return fmt.Errorf("add zip dir %q: %w", f.Name, err)
+		}
+		return nil
+	}
+
+	name, country, language, err := ParseConstraints(f.Name)
+	if err != nil {
+		return fmt.Errorf("add zip file %q: %w", f.Name, err)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("add zip file %q: open: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	buf, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return fmt.Errorf("add zip file %q: read: %w", f.Name, err)
+	}
+
+	if err := w.Add(name, country, language, mt, buf); err != nil {
+		return fmt.Errorf("add zip file %q: %w", f.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package qrc
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestConstraints(t *testing.T) {
+	for _, c := range []struct {
+		n string
+		c Country
+		l Language
+		f string
+	}{
+		{"a.txt", CountryAnyCountry, LanguageC, "a.txt"},
+		{"a", CountryAnyCountry, LanguageC, "a"},
+		{"x/a.tar.gz", CountryCanada, LanguageC, "x/a.tar[country!Canada].gz"},
+		{"a.txt", CountryAnyCountry, LanguageFrench, "a[language!French].txt"},
+		{"a", CountryCanada, LanguageFrench, "a[country!Canada][language!French]"},
+		{"v1.0/a", CountryCanada, LanguageFrench, "v1.0/a[country!Canada][language!French]"},
+	} {
+		if f := FormatConstraints(c.n, c.c, c.l); f != c.f {
+			t.Errorf("format %q %s %s: expected %q, got %q", c.n, c.c, c.l, c.f, f)
+		}
+		if n, x, y, err := ParseConstraints(c.f); err != nil {
+			t.Errorf("parse %q: unexpected error: %v", c.f, err)
+		} else if n != c.n || x != c.c || y != c.l {
+			t.Errorf("parse %q: expected %q %s %s, got %q %s %s", c.f, c.n, c.c, c.l, n, x, y)
+		}
+	}
+	for _, f := range []string{
+		"a[country!Nowhere].txt",
+		"a[language!French][language!French].txt",
+	} {
+		if _, _, _, err := ParseConstraints(f); err == nil {
+			t.Errorf("parse %q: expected error", f)
+		}
+	}
+	if n, _, _, err := ParseConstraints("a[other!thing].txt"); err != nil || n != "a[other!thing].txt" {
+		t.Errorf("parse %q: expected unknown constraint to be kept", n)
+	}
+}
+
+func TestNewWriterFromZip(t *testing.T) {
+	mt := time.Unix(1600000000, 0).UTC()
+
+	var zb bytes.Buffer
+	zw := zip.NewWriter(&zb)
+	for _, f := range []struct {
+		n string
+		d string
+		m time.Time
+	}{
+		{"a.txt", "a", mt},
+		{"dir/", "", mt},
+		{"dir/b[language!French].txt", "b", time.Time{}},
+	} {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.n, Modified: f.m})
+		if err != nil {
+			t.Fatalf("create zip: %v", err)
+		}
+		if _, err := w.Write([]byte(f.d)); err != nil {
+			t.Fatalf("create zip: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(zb.Bytes()), int64(zb.Len()))
+	if err != nil {
+		t.Fatalf("read zip: %v", err)
+	}
+
+	w, err := NewWriterFromZip(zr)
+	if err != nil {
+		t.Fatalf("convert zip: %v", err)
+	}
+
+	var rb bytes.Buffer
+	if err := w.WriteRCC(&rb, 2); err != nil {
+		t.Fatalf("write rcc: %v", err)
+	}
+
+	r, err := NewReaderFromRCC(bytes.NewReader(rb.Bytes()))
+	if err != nil {
+		t.Fatalf("read rcc: %v", err)
+	}
+
+	var n int
+	if err := r.Walk(func(path string, entry *ReaderEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		n++
+		switch path {
+		case "a.txt", "dir":
+			if !entry.ModTime().Equal(mt) {
+				t.Errorf("%q: expected mod time %s, got %s", path, mt, entry.ModTime())
+			}
+		case "dir/b.txt":
+			if c, l := entry.Constraints(); c != CountryAnyCountry || l != LanguageFrench {
+				t.Errorf("%q: incorrect constraints %s %s", path, c, l)
+			}
+			if !entry.ModTime().IsZero() {
+				t.Errorf("%q: expected zero mod time, got %s", path, entry.ModTime())
+			}
+			rc, err := entry.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			if b, err := ioutil.ReadAll(rc); err != nil {
+				return err
+			} else if string(b) != "b" {
+				t.Errorf("%q: incorrect contents %q", path, b)
+			}
+		default:
+			t.Errorf("unexpected file %q", path)
+		}
+		return nil
+	}, false); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 entries, got %d", n)
+	}
+}